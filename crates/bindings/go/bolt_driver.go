@@ -0,0 +1,75 @@
+package grafeo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"grafeo/cypher"
+	"grafeo/driver"
+	"grafeo/wire"
+)
+
+func init() {
+	driver.Register("bolt", boltDriver{})
+}
+
+// boltDriver opens grafeo/wire connections to a remote Grafeo server, the
+// same transport Client uses for "bolt://" addresses. dsn may carry a
+// "bolt://" or "grafeo://" scheme and a query string, both stripped
+// before dialing: only the host:port is meaningful to net.Dial.
+type boltDriver struct{}
+
+func (boltDriver) Open(dsn string) (driver.Conn, error) {
+	addr := dsn
+	if i := strings.Index(addr, "://"); i >= 0 {
+		addr = addr[i+len("://"):]
+	}
+	if i := strings.IndexByte(addr, '?'); i >= 0 {
+		addr = addr[:i]
+	}
+
+	conn, err := wire.Dial(addr)
+	if err != nil {
+		return nil, fmt.Errorf("grafeo: %w", err)
+	}
+	if err := conn.Send(wire.Hello("grafeo-go")); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if _, err := conn.Receive(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("grafeo: HELLO: %w", err)
+	}
+	return &boltConn{conn: conn}, nil
+}
+
+// boltConn adapts a single, persistent grafeo/wire.Conn to driver.Conn,
+// reusing it across every query a pooled grafeo.DB sends it until Close.
+type boltConn struct {
+	conn *wire.Conn
+}
+
+func (c *boltConn) Execute(ctx context.Context, q *cypher.Query, params map[string]any) (driver.Rows, error) {
+	runFields, err := c.conn.Run(q.Raw, params)
+	if err != nil {
+		return nil, err
+	}
+	columns := stringsFromAny(runFields["fields"])
+	return &wireRows{ctx: ctx, conn: c.conn, columns: columns, pos: -1}, nil
+}
+
+func (c *boltConn) Begin(ctx context.Context, opts driver.TxOptions) error {
+	return c.conn.Begin(opts.ReadOnly, opts.Bookmarks)
+}
+
+func (c *boltConn) Commit(ctx context.Context) error { return c.conn.Commit() }
+
+func (c *boltConn) Rollback(ctx context.Context) error { return c.conn.Rollback() }
+
+// Ping sends RESET, which both clears any pending failure state and
+// proves the connection is still alive, so a failed Ping tells DB to
+// evict this connection from its idle pool.
+func (c *boltConn) Ping(ctx context.Context) error { return c.conn.Reset() }
+
+func (c *boltConn) Close() error { return c.conn.Goodbye() }
@@ -0,0 +1,179 @@
+package grafeo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"grafeo/cypher"
+)
+
+// wireRowChunk is how many records Client pulls per PULL message when
+// talking to a server over grafeo/wire, so a large result set streams
+// back incrementally instead of being buffered in full.
+const wireRowChunk = 1000
+
+// Client talks to a remote Grafeo server. The zero value is not usable;
+// construct one with NewClient.
+type Client struct {
+	addr string
+	http *http.Client
+}
+
+// NewClient returns a Client that submits queries to the Grafeo server at
+// addr. A "bolt://" address streams queries over the framed binary
+// protocol in grafeo/wire; any other address (e.g. "http://localhost:7474")
+// submits them as an HTTP form POST.
+func NewClient(addr string) *Client {
+	return &Client{
+		addr: addr,
+		http: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Query parses the given Cypher-subset statement and submits it to the
+// server over the Client's transport (see NewClient). params are passed
+// alongside the query text.
+func (c *Client) Query(ctx context.Context, query string, params map[string]any) (*Result, error) {
+	q, err := cypher.Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("grafeo: parse query: %w", err)
+	}
+	rows, err := c.Execute(ctx, q, params)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{rows: rows}, nil
+}
+
+// Execute implements Executor by submitting the already-parsed query to
+// the server over whichever transport addr selects. It satisfies Executor
+// so Client can be substituted anywhere an Executor is expected (e.g.
+// httpsrv.New or ServeWire).
+func (c *Client) Execute(ctx context.Context, q *cypher.Query, params map[string]any) (Rows, error) {
+	if strings.HasPrefix(c.addr, "bolt://") {
+		return c.executeWire(ctx, q, params)
+	}
+	return c.executeHTTP(ctx, q, params)
+}
+
+func (c *Client) executeHTTP(ctx context.Context, q *cypher.Query, params map[string]any) (Rows, error) {
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("grafeo: encode params: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("query", q.Raw)
+	form.Set("params", string(paramsJSON))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.addr+"/cypher",
+		bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("grafeo: query %s: %w", c.addr, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("grafeo: server returned %s", resp.Status)
+	}
+
+	var body struct {
+		Columns []string `json:"columns"`
+		Rows    [][]any  `json:"rows"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("grafeo: decode response: %w", err)
+	}
+
+	return &memoryRows{columns: body.Columns, data: body.Rows, pos: -1}, nil
+}
+
+// memoryRows is a Rows implementation backed by an already-materialized
+// slice of rows, used to adapt the JSON/HTTP response onto the Rows
+// interface.
+type memoryRows struct {
+	columns []string
+	data    [][]any
+	pos     int
+}
+
+func (r *memoryRows) Columns() []string { return r.columns }
+
+func (r *memoryRows) Next() bool {
+	r.pos++
+	return r.pos < len(r.data)
+}
+
+func (r *memoryRows) Scan(dest ...any) error {
+	if r.pos < 0 || r.pos >= len(r.data) {
+		return fmt.Errorf("grafeo: Scan called without a valid row")
+	}
+	row := r.data[r.pos]
+	if len(dest) != len(row) {
+		return fmt.Errorf("grafeo: Scan expected %d destinations, got %d", len(row), len(dest))
+	}
+	for i, d := range dest {
+		if err := assign(d, row[i]); err != nil {
+			return fmt.Errorf("grafeo: Scan column %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (r *memoryRows) Err() error   { return nil }
+func (r *memoryRows) Close() error { return nil }
+
+// assign copies src into the pointer dest, the same way database/sql
+// assigns scanned columns.
+func assign(dest, src any) error {
+	switch d := dest.(type) {
+	case *any:
+		*d = src
+		return nil
+	case *string:
+		s, ok := src.(string)
+		if !ok {
+			return fmt.Errorf("source is not a string: %T", src)
+		}
+		*d = s
+		return nil
+	case *int64:
+		switch v := src.(type) {
+		case int64:
+			*d = v
+		case float64:
+			*d = int64(v)
+		default:
+			return fmt.Errorf("source is not numeric: %T", src)
+		}
+		return nil
+	case *float64:
+		v, ok := src.(float64)
+		if !ok {
+			return fmt.Errorf("source is not a float: %T", src)
+		}
+		*d = v
+		return nil
+	case *bool:
+		v, ok := src.(bool)
+		if !ok {
+			return fmt.Errorf("source is not a bool: %T", src)
+		}
+		*d = v
+		return nil
+	default:
+		return fmt.Errorf("unsupported Scan destination type %T", dest)
+	}
+}
@@ -0,0 +1,130 @@
+package grafeo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"grafeo/cypher"
+	"grafeo/wire"
+)
+
+// executeWire runs q over a grafeo/wire connection, streaming results back
+// in chunks of wireRowChunk records rather than buffering the whole
+// result set in memory.
+func (c *Client) executeWire(ctx context.Context, q *cypher.Query, params map[string]any) (Rows, error) {
+	conn, err := wire.Dial(strings.TrimPrefix(c.addr, "bolt://"))
+	if err != nil {
+		return nil, fmt.Errorf("grafeo: %w", err)
+	}
+	if err := conn.Send(wire.Hello("grafeo-go")); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if _, err := conn.Receive(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("grafeo: HELLO: %w", err)
+	}
+
+	runFields, err := conn.Run(q.Raw, params)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	columns := stringsFromAny(runFields["fields"])
+
+	return &wireRows{ctx: ctx, conn: conn, columns: columns, pos: -1, ownsConn: true}, nil
+}
+
+func stringsFromAny(v any) []string {
+	items, _ := v.([]any)
+	out := make([]string, len(items))
+	for i, it := range items {
+		out[i], _ = it.(string)
+	}
+	return out
+}
+
+// wireRows is a Rows implementation that pulls records from a grafeo/wire
+// Conn in chunks of wireRowChunk, requesting the next chunk only once the
+// current one is exhausted.
+type wireRows struct {
+	ctx     context.Context
+	conn    *wire.Conn
+	columns []string
+
+	buf     [][]any
+	pos     int
+	hasMore bool
+	started bool
+	err     error
+	closed  bool
+
+	// ownsConn is true when conn was dialed just for this query (Client's
+	// own transport) and should be torn down on Close. boltConn reuses
+	// one persistent conn across many queries for grafeo.DB's pool, and
+	// sets this false so Close only clears the pending result, not the
+	// socket.
+	ownsConn bool
+}
+
+func (r *wireRows) Columns() []string { return r.columns }
+
+func (r *wireRows) Next() bool {
+	if r.err != nil || r.closed {
+		return false
+	}
+	if err := r.ctx.Err(); err != nil {
+		r.err = err
+		return false
+	}
+	r.pos++
+	if r.pos < len(r.buf) {
+		return true
+	}
+	if r.started && !r.hasMore {
+		return false
+	}
+	r.started = true
+	records, hasMore, err := r.conn.Pull(wireRowChunk)
+	if err != nil {
+		r.err = err
+		return false
+	}
+	r.buf = records
+	r.hasMore = hasMore
+	r.pos = 0
+	return len(r.buf) > 0
+}
+
+func (r *wireRows) Scan(dest ...any) error {
+	if r.pos < 0 || r.pos >= len(r.buf) {
+		return fmt.Errorf("grafeo: Scan called without a valid row")
+	}
+	row := r.buf[r.pos]
+	if len(dest) != len(row) {
+		return fmt.Errorf("grafeo: Scan expected %d destinations, got %d", len(row), len(dest))
+	}
+	for i, d := range dest {
+		if err := assign(d, row[i]); err != nil {
+			return fmt.Errorf("grafeo: Scan column %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (r *wireRows) Err() error { return r.err }
+
+func (r *wireRows) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if r.hasMore || !r.started {
+		_ = r.conn.Discard()
+	}
+	if r.ownsConn {
+		_ = r.conn.Goodbye()
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package cypher
+
+// Query is the root of a parsed Cypher statement: zero or more reading
+// clauses (MATCH) followed by writing clauses (CREATE, MERGE, SET, DELETE)
+// and an optional RETURN.
+type Query struct {
+	// Raw is the original source text the query was parsed from. Backends
+	// that forward queries verbatim (e.g. the remote HTTP client) use this
+	// instead of re-deriving text from the AST.
+	Raw     string
+	Matches []*MatchClause
+	Creates []*CreateClause
+	Merges  []*MergeClause
+	Sets    []*SetClause
+	Deletes []*DeleteClause
+	Return  *ReturnClause
+}
+
+// MatchClause is a `MATCH <pattern> [WHERE <expr>]` clause.
+type MatchClause struct {
+	Pattern *Pattern
+	Where   Expr
+}
+
+// CreateClause is a `CREATE <pattern>` clause.
+type CreateClause struct {
+	Pattern *Pattern
+}
+
+// MergeClause is a `MERGE <pattern>` clause.
+type MergeClause struct {
+	Pattern *Pattern
+}
+
+// SetClause is a `SET <target>.<prop> = <expr>, ...` clause.
+type SetClause struct {
+	Items []*SetItem
+}
+
+// SetItem is a single `var.prop = expr` assignment within a SET clause.
+type SetItem struct {
+	Variable string
+	Property string
+	Value    Expr
+}
+
+// DeleteClause is a `DELETE <var>, ...` clause. Detach indicates `DETACH
+// DELETE`, which also removes incident relationships.
+type DeleteClause struct {
+	Variables []string
+	Detach    bool
+}
+
+// ReturnClause is a `RETURN <items>` clause.
+type ReturnClause struct {
+	Items []*ReturnItem
+}
+
+// ReturnItem is a single projected expression, optionally aliased with `AS`.
+type ReturnItem struct {
+	Expr  Expr
+	Alias string
+}
+
+// Pattern is a sequence of node patterns connected by relationship
+// patterns, e.g. `(a:Label)-[:REL]->(b)-[:OTHER*1..3]-(c)`.
+type Pattern struct {
+	Nodes []*NodePattern
+	Rels  []*RelPattern // len(Rels) == len(Nodes)-1
+}
+
+// NodePattern is a single `(var:Label1:Label2 {prop: val, ...})` pattern.
+type NodePattern struct {
+	Variable   string
+	Labels     []string
+	Properties map[string]Expr
+}
+
+// Direction is the direction of a relationship pattern relative to the
+// pattern's written order.
+type Direction int
+
+const (
+	DirOutgoing Direction = iota // -[...]->
+	DirIncoming                  // <-[...]-
+	DirEither                    // -[...]-
+)
+
+// RelPattern is a single `-[var:TYPE*min..max {prop: val}]->` pattern.
+type RelPattern struct {
+	Variable   string
+	Types      []string
+	Properties map[string]Expr
+	Direction  Direction
+
+	// Variable-length bounds. MinHops == MaxHops == 1 for a plain,
+	// non-variable-length relationship.
+	VarLength bool // true if `*` was present at all
+	MinHops   int
+	MaxHops   int // -1 means unbounded
+}
+
+// Expr is any scalar expression: a literal, parameter, property access, or
+// binary comparison/boolean combination.
+type Expr interface {
+	exprNode()
+}
+
+// Literal is an int64, float64, string, or bool constant, or nil for NULL.
+type Literal struct {
+	Value any
+}
+
+// Param is a `$name` query parameter reference.
+type Param struct {
+	Name string
+}
+
+// PropertyAccess is a `var.prop` expression.
+type PropertyAccess struct {
+	Variable string
+	Property string
+}
+
+// VarRef is a bare `var` reference, e.g. in `RETURN a`.
+type VarRef struct {
+	Variable string
+}
+
+// BinaryExpr is `<left> <op> <right>`, where Op is one of "=", "<>", "<",
+// "<=", ">", ">=", "AND", "OR".
+type BinaryExpr struct {
+	Op    string
+	Left  Expr
+	Right Expr
+}
+
+func (*Literal) exprNode()        {}
+func (*Param) exprNode()          {}
+func (*PropertyAccess) exprNode() {}
+func (*VarRef) exprNode()         {}
+func (*BinaryExpr) exprNode()     {}
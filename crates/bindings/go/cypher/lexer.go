@@ -0,0 +1,227 @@
+package cypher
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Lexer turns Cypher source text into a stream of Tokens.
+type Lexer struct {
+	src string
+	pos int
+}
+
+// NewLexer returns a Lexer over src.
+func NewLexer(src string) *Lexer {
+	return &Lexer{src: src}
+}
+
+func (l *Lexer) peekRune() (rune, int) {
+	if l.pos >= len(l.src) {
+		return 0, 0
+	}
+	r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+	return r, size
+}
+
+// peekRuneAt decodes the rune starting at byte offset pos, without
+// moving l.pos, so callers can look one rune past the current one.
+func (l *Lexer) peekRuneAt(pos int) (rune, int) {
+	if pos >= len(l.src) {
+		return 0, 0
+	}
+	r, size := utf8.DecodeRuneInString(l.src[pos:])
+	return r, size
+}
+
+func (l *Lexer) skipSpace() {
+	for {
+		r, size := l.peekRune()
+		if size == 0 || !unicode.IsSpace(r) {
+			return
+		}
+		l.pos += size
+	}
+}
+
+// Next returns the next Token in the stream, or a TokEOF token once the
+// input is exhausted.
+func (l *Lexer) Next() (Token, error) {
+	l.skipSpace()
+	start := l.pos
+	r, size := l.peekRune()
+	if size == 0 {
+		return Token{Kind: TokEOF, Pos: start}, nil
+	}
+
+	switch {
+	case r == '(':
+		l.pos += size
+		return Token{Kind: TokLParen, Text: "(", Pos: start}, nil
+	case r == ')':
+		l.pos += size
+		return Token{Kind: TokRParen, Text: ")", Pos: start}, nil
+	case r == '[':
+		l.pos += size
+		return Token{Kind: TokLBracket, Text: "[", Pos: start}, nil
+	case r == ']':
+		l.pos += size
+		return Token{Kind: TokRBracket, Text: "]", Pos: start}, nil
+	case r == '{':
+		l.pos += size
+		return Token{Kind: TokLBrace, Text: "{", Pos: start}, nil
+	case r == '}':
+		l.pos += size
+		return Token{Kind: TokRBrace, Text: "}", Pos: start}, nil
+	case r == ',':
+		l.pos += size
+		return Token{Kind: TokComma, Text: ",", Pos: start}, nil
+	case r == '.':
+		l.pos += size
+		if r2, s2 := l.peekRune(); r2 == '.' {
+			l.pos += s2
+			return Token{Kind: TokDotDot, Text: "..", Pos: start}, nil
+		}
+		return Token{Kind: TokDot, Text: ".", Pos: start}, nil
+	case r == ':':
+		l.pos += size
+		return Token{Kind: TokColon, Text: ":", Pos: start}, nil
+	case r == '*':
+		l.pos += size
+		return Token{Kind: TokStar, Text: "*", Pos: start}, nil
+	case r == '=':
+		l.pos += size
+		return Token{Kind: TokEq, Text: "=", Pos: start}, nil
+	case r == '<':
+		l.pos += size
+		if r2, s2 := l.peekRune(); r2 == '>' {
+			l.pos += s2
+			return Token{Kind: TokNeq, Text: "<>", Pos: start}, nil
+		} else if r2 == '-' {
+			l.pos += s2
+			return Token{Kind: TokArrowLeft, Text: "<-", Pos: start}, nil
+		} else if r2 == '=' {
+			l.pos += s2
+			return Token{Kind: TokLte, Text: "<=", Pos: start}, nil
+		}
+		return Token{Kind: TokLt, Text: "<", Pos: start}, nil
+	case r == '>':
+		l.pos += size
+		if r2, s2 := l.peekRune(); r2 == '=' {
+			l.pos += s2
+			return Token{Kind: TokGte, Text: ">=", Pos: start}, nil
+		}
+		return Token{Kind: TokGt, Text: ">", Pos: start}, nil
+	case r == '-':
+		l.pos += size
+		if r2, s2 := l.peekRune(); r2 == '>' {
+			l.pos += s2
+			return Token{Kind: TokArrowRight, Text: "->", Pos: start}, nil
+		}
+		return Token{Kind: TokDash, Text: "-", Pos: start}, nil
+	case r == '$':
+		l.pos += size
+		nstart := l.pos
+		for {
+			r2, s2 := l.peekRune()
+			if s2 == 0 || !(unicode.IsLetter(r2) || unicode.IsDigit(r2) || r2 == '_') {
+				break
+			}
+			l.pos += s2
+		}
+		return Token{Kind: TokParam, Text: l.src[nstart:l.pos], Pos: start}, nil
+	case r == '\'' || r == '"':
+		return l.lexString(r)
+	case unicode.IsDigit(r):
+		return l.lexNumber()
+	case unicode.IsLetter(r) || r == '_':
+		for {
+			r2, s2 := l.peekRune()
+			if s2 == 0 || !(unicode.IsLetter(r2) || unicode.IsDigit(r2) || r2 == '_') {
+				break
+			}
+			l.pos += s2
+		}
+		return Token{Kind: TokIdent, Text: l.src[start:l.pos], Pos: start}, nil
+	default:
+		return Token{}, fmt.Errorf("cypher: unexpected character %q at position %d", r, start)
+	}
+}
+
+func (l *Lexer) lexString(quote rune) (Token, error) {
+	start := l.pos
+	l.pos += utf8.RuneLen(quote)
+	var sb strings.Builder
+	for {
+		r, size := l.peekRune()
+		if size == 0 {
+			return Token{}, fmt.Errorf("cypher: unterminated string literal at position %d", start)
+		}
+		if r == quote {
+			l.pos += size
+			break
+		}
+		if r == '\\' {
+			l.pos += size
+			r2, s2 := l.peekRune()
+			if s2 == 0 {
+				return Token{}, fmt.Errorf("cypher: unterminated escape at position %d", l.pos)
+			}
+			l.pos += s2
+			sb.WriteRune(unescape(r2))
+			continue
+		}
+		l.pos += size
+		sb.WriteRune(r)
+	}
+	return Token{Kind: TokString, Text: sb.String(), Pos: start}, nil
+}
+
+func unescape(r rune) rune {
+	switch r {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	default:
+		return r
+	}
+}
+
+func (l *Lexer) lexNumber() (Token, error) {
+	start := l.pos
+	isFloat := false
+	for {
+		r, size := l.peekRune()
+		if size == 0 {
+			break
+		}
+		if r == '.' {
+			if isFloat {
+				break
+			}
+			// A '.' immediately followed by another '.' starts a range
+			// operator (e.g. the `1..3` in `*1..3`), not a decimal point;
+			// leave both dots for Next to lex as TokDotDot.
+			if r2, _ := l.peekRuneAt(l.pos + size); r2 == '.' {
+				break
+			}
+			isFloat = true
+			l.pos += size
+			continue
+		}
+		if !unicode.IsDigit(r) {
+			break
+		}
+		l.pos += size
+	}
+	kind := TokInt
+	if isFloat {
+		kind = TokFloat
+	}
+	return Token{Kind: kind, Text: l.src[start:l.pos], Pos: start}, nil
+}
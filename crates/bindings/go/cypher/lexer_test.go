@@ -0,0 +1,34 @@
+package cypher
+
+import "testing"
+
+func TestLexerVariableLengthRange(t *testing.T) {
+	l := NewLexer("*1..3")
+	want := []TokenKind{TokStar, TokInt, TokDotDot, TokInt, TokEOF}
+	for i, k := range want {
+		tok, err := l.Next()
+		if err != nil {
+			t.Fatalf("token %d: %v", i, err)
+		}
+		if tok.Kind != k {
+			t.Fatalf("token %d: got kind %d text %q, want kind %d", i, tok.Kind, tok.Text, k)
+		}
+	}
+}
+
+func TestLexerFloat(t *testing.T) {
+	l := NewLexer("3.14")
+	tok, err := l.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.Kind != TokFloat || tok.Text != "3.14" {
+		t.Fatalf("got kind %d text %q, want TokFloat \"3.14\"", tok.Kind, tok.Text)
+	}
+}
+
+func TestParseVariableLengthPattern(t *testing.T) {
+	if _, err := Parse("MATCH (a)-[:REL*1..3]->(b) RETURN a"); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+}
@@ -0,0 +1,574 @@
+package cypher
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parser is a recursive-descent parser that turns Cypher source into a
+// *Query AST. Use Parse for one-shot parsing.
+type Parser struct {
+	lex  *Lexer
+	tok  Token
+	peek *Token
+}
+
+// Parse parses a single Cypher statement.
+func Parse(src string) (*Query, error) {
+	p := &Parser{lex: NewLexer(src)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	q, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	q.Raw = src
+	return q, nil
+}
+
+func (p *Parser) advance() error {
+	if p.peek != nil {
+		p.tok = *p.peek
+		p.peek = nil
+		return nil
+	}
+	t, err := p.lex.Next()
+	if err != nil {
+		return err
+	}
+	p.tok = t
+	return nil
+}
+
+func (p *Parser) keywordIs(kw string) bool {
+	return p.tok.Kind == TokIdent && strings.EqualFold(p.tok.Text, kw)
+}
+
+func (p *Parser) expectKeyword(kw string) error {
+	if !p.keywordIs(kw) {
+		return fmt.Errorf("cypher: expected %q at position %d, got %q", kw, p.tok.Pos, p.tok.Text)
+	}
+	return p.advance()
+}
+
+func (p *Parser) expect(kind TokenKind, what string) (Token, error) {
+	if p.tok.Kind != kind {
+		return Token{}, fmt.Errorf("cypher: expected %s at position %d, got %q", what, p.tok.Pos, p.tok.Text)
+	}
+	t := p.tok
+	return t, p.advance()
+}
+
+func (p *Parser) parseQuery() (*Query, error) {
+	q := &Query{}
+	for {
+		switch {
+		case p.keywordIs("MATCH"):
+			m, err := p.parseMatch()
+			if err != nil {
+				return nil, err
+			}
+			q.Matches = append(q.Matches, m)
+		case p.keywordIs("CREATE"):
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			pat, err := p.parsePattern()
+			if err != nil {
+				return nil, err
+			}
+			q.Creates = append(q.Creates, &CreateClause{Pattern: pat})
+		case p.keywordIs("MERGE"):
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			pat, err := p.parsePattern()
+			if err != nil {
+				return nil, err
+			}
+			q.Merges = append(q.Merges, &MergeClause{Pattern: pat})
+		case p.keywordIs("SET"):
+			s, err := p.parseSet()
+			if err != nil {
+				return nil, err
+			}
+			q.Sets = append(q.Sets, s)
+		case p.keywordIs("DELETE"):
+			d, err := p.parseDelete(false)
+			if err != nil {
+				return nil, err
+			}
+			q.Deletes = append(q.Deletes, d)
+		case p.keywordIs("DETACH"):
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expectKeyword("DELETE"); err != nil {
+				return nil, err
+			}
+			d, err := p.parseDelete(true)
+			if err != nil {
+				return nil, err
+			}
+			q.Deletes = append(q.Deletes, d)
+		case p.keywordIs("RETURN"):
+			r, err := p.parseReturn()
+			if err != nil {
+				return nil, err
+			}
+			q.Return = r
+		case p.tok.Kind == TokEOF:
+			return q, nil
+		default:
+			return nil, fmt.Errorf("cypher: unexpected token %q at position %d", p.tok.Text, p.tok.Pos)
+		}
+	}
+}
+
+func (p *Parser) parseMatch() (*MatchClause, error) {
+	if err := p.advance(); err != nil { // consume MATCH
+		return nil, err
+	}
+	pat, err := p.parsePattern()
+	if err != nil {
+		return nil, err
+	}
+	m := &MatchClause{Pattern: pat}
+	if p.keywordIs("WHERE") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		m.Where = expr
+	}
+	return m, nil
+}
+
+// parsePattern parses `(a:Label)-[:REL]->(b)-[:T2]-(c)`.
+func (p *Parser) parsePattern() (*Pattern, error) {
+	pat := &Pattern{}
+	n, err := p.parseNodePattern()
+	if err != nil {
+		return nil, err
+	}
+	pat.Nodes = append(pat.Nodes, n)
+	for p.tok.Kind == TokDash || p.tok.Kind == TokArrowLeft {
+		rel, err := p.parseRelPattern()
+		if err != nil {
+			return nil, err
+		}
+		pat.Rels = append(pat.Rels, rel)
+		n, err := p.parseNodePattern()
+		if err != nil {
+			return nil, err
+		}
+		pat.Nodes = append(pat.Nodes, n)
+	}
+	return pat, nil
+}
+
+func (p *Parser) parseNodePattern() (*NodePattern, error) {
+	if _, err := p.expect(TokLParen, "'('"); err != nil {
+		return nil, err
+	}
+	n := &NodePattern{}
+	if p.tok.Kind == TokIdent {
+		n.Variable = p.tok.Text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	for p.tok.Kind == TokColon {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		lbl, err := p.expect(TokIdent, "label")
+		if err != nil {
+			return nil, err
+		}
+		n.Labels = append(n.Labels, lbl.Text)
+	}
+	if p.tok.Kind == TokLBrace {
+		props, err := p.parsePropertyMap()
+		if err != nil {
+			return nil, err
+		}
+		n.Properties = props
+	}
+	if _, err := p.expect(TokRParen, "')'"); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (p *Parser) parseRelPattern() (*RelPattern, error) {
+	rel := &RelPattern{Direction: DirEither, MinHops: 1, MaxHops: 1}
+	leftArrow := false
+	if p.tok.Kind == TokArrowLeft {
+		leftArrow = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := p.expect(TokDash, "'-'"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.tok.Kind == TokLBracket {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.Kind == TokIdent {
+			rel.Variable = p.tok.Text
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		for p.tok.Kind == TokColon {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			t, err := p.expect(TokIdent, "relationship type")
+			if err != nil {
+				return nil, err
+			}
+			rel.Types = append(rel.Types, t.Text)
+			// allow `:TYPE1|TYPE2` style alternation via repeated colons is
+			// uncommon; `|` alternation is not supported in this subset.
+		}
+		if p.tok.Kind == TokStar {
+			rel.VarLength = true
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			rel.MinHops, rel.MaxHops = 1, -1
+			if p.tok.Kind == TokInt {
+				min, err := strconv.Atoi(p.tok.Text)
+				if err != nil {
+					return nil, err
+				}
+				rel.MinHops = min
+				rel.MaxHops = min
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+				if p.tok.Kind == TokDotDot {
+					if err := p.advance(); err != nil {
+						return nil, err
+					}
+					rel.MaxHops = -1
+					if p.tok.Kind == TokInt {
+						max, err := strconv.Atoi(p.tok.Text)
+						if err != nil {
+							return nil, err
+						}
+						rel.MaxHops = max
+						if err := p.advance(); err != nil {
+							return nil, err
+						}
+					}
+				}
+			}
+		}
+		if p.tok.Kind == TokLBrace {
+			props, err := p.parsePropertyMap()
+			if err != nil {
+				return nil, err
+			}
+			rel.Properties = props
+		}
+		if _, err := p.expect(TokRBracket, "']'"); err != nil {
+			return nil, err
+		}
+	}
+
+	rightArrow := false
+	if p.tok.Kind == TokArrowRight {
+		rightArrow = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := p.expect(TokDash, "'-'"); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case leftArrow && !rightArrow:
+		rel.Direction = DirIncoming
+	case rightArrow && !leftArrow:
+		rel.Direction = DirOutgoing
+	default:
+		rel.Direction = DirEither
+	}
+	return rel, nil
+}
+
+func (p *Parser) parsePropertyMap() (map[string]Expr, error) {
+	if _, err := p.expect(TokLBrace, "'{'"); err != nil {
+		return nil, err
+	}
+	props := map[string]Expr{}
+	for p.tok.Kind != TokRBrace {
+		key, err := p.expect(TokIdent, "property key")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(TokColon, "':'"); err != nil {
+			return nil, err
+		}
+		val, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		props[key.Text] = val
+		if p.tok.Kind == TokComma {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, err := p.expect(TokRBrace, "'}'"); err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+func (p *Parser) parseSet() (*SetClause, error) {
+	if err := p.advance(); err != nil { // consume SET
+		return nil, err
+	}
+	s := &SetClause{}
+	for {
+		variable, err := p.expect(TokIdent, "variable")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(TokDot, "'.'"); err != nil {
+			return nil, err
+		}
+		prop, err := p.expect(TokIdent, "property")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(TokEq, "'='"); err != nil {
+			return nil, err
+		}
+		val, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		s.Items = append(s.Items, &SetItem{Variable: variable.Text, Property: prop.Text, Value: val})
+		if p.tok.Kind != TokComma {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (p *Parser) parseDelete(detach bool) (*DeleteClause, error) {
+	if err := p.advance(); err != nil { // consume DELETE
+		return nil, err
+	}
+	d := &DeleteClause{Detach: detach}
+	for {
+		v, err := p.expect(TokIdent, "variable")
+		if err != nil {
+			return nil, err
+		}
+		d.Variables = append(d.Variables, v.Text)
+		if p.tok.Kind != TokComma {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (p *Parser) parseReturn() (*ReturnClause, error) {
+	if err := p.advance(); err != nil { // consume RETURN
+		return nil, err
+	}
+	r := &ReturnClause{}
+	for {
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		item := &ReturnItem{Expr: expr}
+		if p.keywordIs("AS") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			alias, err := p.expect(TokIdent, "alias")
+			if err != nil {
+				return nil, err
+			}
+			item.Alias = alias.Text
+		}
+		r.Items = append(r.Items, item)
+		if p.tok.Kind != TokComma {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// parseExpr parses boolean OR/AND of comparisons, e.g.
+// `a.age > 21 AND b.name = 'x'`.
+func (p *Parser) parseExpr() (Expr, error) {
+	return p.parseOr()
+}
+
+func (p *Parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keywordIs("OR") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Op: "OR", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *Parser) parseAnd() (Expr, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.keywordIs("AND") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Op: "AND", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *Parser) parseComparison() (Expr, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	op := ""
+	switch p.tok.Kind {
+	case TokEq:
+		op = "="
+	case TokNeq:
+		op = "<>"
+	case TokLt:
+		op = "<"
+	case TokLte:
+		op = "<="
+	case TokGt:
+		op = ">"
+	case TokGte:
+		op = ">="
+	default:
+		return left, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	return &BinaryExpr{Op: op, Left: left, Right: right}, nil
+}
+
+func (p *Parser) parsePrimary() (Expr, error) {
+	switch p.tok.Kind {
+	case TokInt:
+		v, err := strconv.ParseInt(p.tok.Text, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &Literal{Value: v}, nil
+	case TokFloat:
+		v, err := strconv.ParseFloat(p.tok.Text, 64)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &Literal{Value: v}, nil
+	case TokString:
+		v := p.tok.Text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &Literal{Value: v}, nil
+	case TokParam:
+		name := p.tok.Text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &Param{Name: name}, nil
+	case TokIdent:
+		switch strings.ToUpper(p.tok.Text) {
+		case "TRUE":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			return &Literal{Value: true}, nil
+		case "FALSE":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			return &Literal{Value: false}, nil
+		case "NULL":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			return &Literal{Value: nil}, nil
+		}
+		name := p.tok.Text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.Kind == TokDot {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			prop, err := p.expect(TokIdent, "property")
+			if err != nil {
+				return nil, err
+			}
+			return &PropertyAccess{Variable: name, Property: prop.Text}, nil
+		}
+		return &VarRef{Variable: name}, nil
+	default:
+		return nil, fmt.Errorf("cypher: unexpected token %q at position %d", p.tok.Text, p.tok.Pos)
+	}
+}
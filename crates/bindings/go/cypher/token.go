@@ -0,0 +1,47 @@
+package cypher
+
+// TokenKind identifies the lexical class of a Token.
+type TokenKind int
+
+const (
+	TokEOF TokenKind = iota
+	TokIdent
+	TokInt
+	TokFloat
+	TokString
+	TokParam // $name
+
+	TokLParen   // (
+	TokRParen   // )
+	TokLBracket // [
+	TokRBracket // ]
+	TokLBrace   // {
+	TokRBrace   // }
+	TokColon    // :
+	TokComma    // ,
+	TokDot      // .
+	TokStar     // *
+	TokDotDot   // ..
+
+	TokEq  // =
+	TokNeq // <>
+	TokLt
+	TokLte
+	TokGt
+	TokGte
+
+	TokArrowRight // ->
+	TokArrowLeft  // <-
+	TokDash       // -
+
+	// Keywords are re-tagged to TokIdent by the lexer and recognized by the
+	// parser via the literal text so that they stay usable as identifiers
+	// (property names, aliases) where Cypher itself allows it.
+)
+
+// Token is a single lexical token produced by the Lexer.
+type Token struct {
+	Kind TokenKind
+	Text string
+	Pos  int
+}
@@ -0,0 +1,204 @@
+package grafeo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"grafeo/cypher"
+	"grafeo/driver"
+)
+
+// DB is a pooled handle to a Grafeo backend, opened with Open. It keeps a
+// small pool of idle connections, health-checks one before handing it
+// back out, and reconnects with exponential backoff when dialing fails,
+// the same shape database/sql.DB gives its drivers.
+type DB struct {
+	dsn string
+	drv driver.Driver
+
+	mu      sync.Mutex
+	idle    []driver.Conn
+	maxIdle int
+}
+
+// Open returns a DB that opens connections to dsn using the driver
+// registered under name (see grafeo/driver.Register). The built-in
+// "bolt" driver reaches a remote server over grafeo/wire; importing
+// grafeo/memgraph registers a "memory" driver backed by an embedded
+// Graph. Open does not dial immediately; the first connection is opened
+// lazily by Query or Begin.
+func Open(name, dsn string) (*DB, error) {
+	drv, ok := driver.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("grafeo: unknown driver %q (forgotten import of its package?); registered: %v", name, driver.Names())
+	}
+	return &DB{dsn: dsn, drv: drv, maxIdle: 4}, nil
+}
+
+// Query parses query, acquires a pooled connection, and runs it. The
+// connection returns to the pool when the *Result is closed.
+func (db *DB) Query(ctx context.Context, query string, params map[string]any) (*Result, error) {
+	q, err := cypher.Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("grafeo: parse query: %w", err)
+	}
+	c, err := db.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.Execute(ctx, q, params)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	return &Result{rows: &pooledRows{Rows: rows, db: db, conn: c}}, nil
+}
+
+// TxOptions configures a transaction started with DB.Begin.
+type TxOptions struct {
+	// ReadOnly hints to the backend that no writes will be issued, which
+	// a cluster may use to route the transaction to a replica.
+	ReadOnly bool
+	// Bookmarks are causal-consistency tokens from prior transactions
+	// this one should wait to observe before starting.
+	Bookmarks []string
+}
+
+// Tx batches statements against a single pooled connection as one
+// explicit transaction, committed or rolled back by the caller.
+type Tx struct {
+	db   *DB
+	conn driver.Conn
+	done bool
+}
+
+// Begin acquires a pooled connection and starts an explicit transaction
+// on it. The connection is held for the lifetime of the Tx and only
+// returns to the pool once Commit or Rollback is called.
+func (db *DB) Begin(ctx context.Context, opts TxOptions) (*Tx, error) {
+	c, err := db.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Begin(ctx, driver.TxOptions{ReadOnly: opts.ReadOnly, Bookmarks: opts.Bookmarks}); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return &Tx{db: db, conn: c}, nil
+}
+
+// Query parses query and runs it inside the transaction.
+func (tx *Tx) Query(ctx context.Context, query string, params map[string]any) (*Result, error) {
+	if tx.done {
+		return nil, fmt.Errorf("grafeo: transaction already committed or rolled back")
+	}
+	q, err := cypher.Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("grafeo: parse query: %w", err)
+	}
+	rows, err := tx.conn.Execute(ctx, q, params)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{rows: rows}, nil
+}
+
+// Commit commits the transaction and returns its connection to the pool.
+func (tx *Tx) Commit(ctx context.Context) error {
+	return tx.end(ctx, tx.conn.Commit)
+}
+
+// Rollback rolls back the transaction and returns its connection to the
+// pool.
+func (tx *Tx) Rollback(ctx context.Context) error {
+	return tx.end(ctx, tx.conn.Rollback)
+}
+
+func (tx *Tx) end(ctx context.Context, finish func(context.Context) error) error {
+	if tx.done {
+		return fmt.Errorf("grafeo: transaction already committed or rolled back")
+	}
+	tx.done = true
+	if err := finish(ctx); err != nil {
+		tx.conn.Close()
+		return err
+	}
+	tx.db.release(tx.conn)
+	return nil
+}
+
+// acquire returns a healthy idle connection from the pool, or dials a
+// fresh one with exponential backoff if none is idle or the idle
+// connection fails its health check.
+func (db *DB) acquire(ctx context.Context) (driver.Conn, error) {
+	for {
+		db.mu.Lock()
+		n := len(db.idle)
+		if n == 0 {
+			db.mu.Unlock()
+			return db.dialWithBackoff(ctx)
+		}
+		c := db.idle[n-1]
+		db.idle = db.idle[:n-1]
+		db.mu.Unlock()
+
+		if err := c.Ping(ctx); err == nil {
+			return c, nil
+		}
+		c.Close()
+	}
+}
+
+func (db *DB) dialWithBackoff(ctx context.Context) (driver.Conn, error) {
+	const maxAttempts = 5
+	backoff := 25 * time.Millisecond
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			backoff *= 2
+		}
+		c, err := db.drv.Open(db.dsn)
+		if err == nil {
+			return c, nil
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("grafeo: open %q after %d attempts: %w", db.dsn, maxAttempts, lastErr)
+}
+
+func (db *DB) release(c driver.Conn) {
+	db.mu.Lock()
+	if len(db.idle) < db.maxIdle {
+		db.idle = append(db.idle, c)
+		db.mu.Unlock()
+		return
+	}
+	db.mu.Unlock()
+	c.Close()
+}
+
+// pooledRows wraps a driver.Rows so that closing it returns the
+// connection that produced it to db's idle pool instead of leaking it.
+type pooledRows struct {
+	driver.Rows
+	db     *DB
+	conn   driver.Conn
+	closed bool
+}
+
+func (p *pooledRows) Close() error {
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	err := p.Rows.Close()
+	p.db.release(p.conn)
+	return err
+}
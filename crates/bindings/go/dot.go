@@ -0,0 +1,282 @@
+package grafeo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"grafeo/wire"
+)
+
+// DOTNode is anything WriteDOT-style rendering can treat as a graph node:
+// an identity, its labels, and its properties. wire.Node and
+// *grafeo/memgraph.Node both satisfy this structurally, with no import
+// relationship required between the packages.
+type DOTNode interface {
+	DOTID() int64
+	DOTLabels() []string
+	DOTProperties() map[string]any
+}
+
+// DOTEdge is anything WriteDOT-style rendering can treat as a directed
+// relationship. wire.Relationship and *grafeo/memgraph.Edge both satisfy
+// this structurally.
+type DOTEdge interface {
+	DOTID() int64
+	DOTType() string
+	DOTFrom() int64
+	DOTTo() int64
+	DOTProperties() map[string]any
+}
+
+// DOTOptions controls how WriteDOT and WriteDOTGraph render nodes and
+// relationships as Graphviz DOT.
+type DOTOptions struct {
+	// NodeLabel is a text/template string executed against each node
+	// (fields .ID, .Labels, .Properties) to produce its DOT label.
+	// Defaults to printing the node's labels.
+	NodeLabel string
+	// EdgeLabel is a text/template string executed against each edge
+	// (fields .ID, .Type, .Properties). Defaults to the relationship type.
+	EdgeLabel string
+	// NodeColor maps a label to a Graphviz fill color; a node carrying
+	// several colored labels uses the first one, in the node's own label
+	// order.
+	NodeColor map[string]string
+	// EdgeStyle maps a relationship type to a Graphviz edge style, e.g.
+	// "dashed" or "bold".
+	EdgeStyle map[string]string
+	// ClusterBy, if set, groups nodes into Graphviz subgraphs: nodes
+	// carrying this as a label cluster together under it, otherwise nodes
+	// are clustered by the string value of a property with this name.
+	// Nodes matching neither are left unclustered.
+	ClusterBy string
+	// RecordShape renders each node as an HTML-like table of its
+	// properties instead of a plain text label.
+	RecordShape bool
+}
+
+// WriteDOT renders a query Result as Graphviz DOT. Any column whose value
+// is a whole node, relationship, or path (e.g. `RETURN n`, `RETURN path`)
+// contributes to the rendered graph; scalar columns are ignored. Results
+// returned from a `MATCH ... RETURN path` query can be piped straight to
+// `dot -Tsvg`.
+func WriteDOT(w io.Writer, result *Result, opts *DOTOptions) error {
+	cols := result.Columns()
+	nodesByID := map[int64]DOTNode{}
+	edgesByID := map[int64]DOTEdge{}
+
+	for result.Next() {
+		dest := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range dest {
+			ptrs[i] = &dest[i]
+		}
+		if err := result.Scan(ptrs...); err != nil {
+			return err
+		}
+		for _, v := range dest {
+			collectDOTElements(v, nodesByID, edgesByID)
+		}
+	}
+	if err := result.Err(); err != nil {
+		return err
+	}
+
+	nodes := make([]DOTNode, 0, len(nodesByID))
+	for _, n := range nodesByID {
+		nodes = append(nodes, n)
+	}
+	edges := make([]DOTEdge, 0, len(edgesByID))
+	for _, e := range edgesByID {
+		edges = append(edges, e)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].DOTID() < nodes[j].DOTID() })
+	sort.Slice(edges, func(i, j int) bool { return edges[i].DOTID() < edges[j].DOTID() })
+
+	return WriteDOTGraph(w, nodes, edges, opts)
+}
+
+func collectDOTElements(v any, nodes map[int64]DOTNode, edges map[int64]DOTEdge) {
+	switch x := v.(type) {
+	case wire.Node:
+		nodes[x.DOTID()] = x
+	case wire.Relationship:
+		edges[x.DOTID()] = x
+	case wire.Path:
+		for _, n := range x.Nodes {
+			nodes[n.DOTID()] = n
+		}
+		for _, r := range x.Relationships {
+			edges[r.DOTID()] = r
+		}
+	case DOTNode:
+		nodes[x.DOTID()] = x
+	case DOTEdge:
+		edges[x.DOTID()] = x
+	}
+}
+
+// WriteDOTGraph renders an explicit set of nodes and edges as Graphviz
+// DOT. grafeo/memgraph.Graph.WriteDOT builds its node/edge lists from the
+// in-memory graph and calls this directly.
+func WriteDOTGraph(w io.Writer, nodes []DOTNode, edges []DOTEdge, opts *DOTOptions) error {
+	if opts == nil {
+		opts = &DOTOptions{}
+	}
+	nodeTmpl, err := compileDOTTemplate("node", opts.NodeLabel, "{{range .Labels}}{{.}} {{end}}")
+	if err != nil {
+		return fmt.Errorf("grafeo: NodeLabel: %w", err)
+	}
+	edgeTmpl, err := compileDOTTemplate("edge", opts.EdgeLabel, "{{.Type}}")
+	if err != nil {
+		return fmt.Errorf("grafeo: EdgeLabel: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("digraph grafeo {\n")
+
+	clustered := map[string][]DOTNode{}
+	var unclustered []DOTNode
+	for _, n := range nodes {
+		key := dotClusterKey(n, opts.ClusterBy)
+		if key == "" {
+			unclustered = append(unclustered, n)
+		} else {
+			clustered[key] = append(clustered[key], n)
+		}
+	}
+	clusterNames := make([]string, 0, len(clustered))
+	for name := range clustered {
+		clusterNames = append(clusterNames, name)
+	}
+	sort.Strings(clusterNames)
+
+	for i, name := range clusterNames {
+		fmt.Fprintf(&buf, "  subgraph cluster_%d {\n    label=%q;\n", i, name)
+		for _, n := range clustered[name] {
+			if err := writeDOTNode(&buf, n, opts, nodeTmpl, "    "); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("  }\n")
+	}
+	for _, n := range unclustered {
+		if err := writeDOTNode(&buf, n, opts, nodeTmpl, "  "); err != nil {
+			return err
+		}
+	}
+	for _, e := range edges {
+		if err := writeDOTEdge(&buf, e, opts, edgeTmpl); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString("}\n")
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func compileDOTTemplate(name, custom, fallback string) (*template.Template, error) {
+	src := custom
+	if src == "" {
+		src = fallback
+	}
+	return template.New(name).Parse(src)
+}
+
+type dotNodeView struct{ n DOTNode }
+
+func (v dotNodeView) ID() int64                  { return v.n.DOTID() }
+func (v dotNodeView) Labels() []string           { return v.n.DOTLabels() }
+func (v dotNodeView) Properties() map[string]any { return v.n.DOTProperties() }
+
+type dotEdgeView struct{ e DOTEdge }
+
+func (v dotEdgeView) ID() int64                  { return v.e.DOTID() }
+func (v dotEdgeView) Type() string                { return v.e.DOTType() }
+func (v dotEdgeView) Properties() map[string]any { return v.e.DOTProperties() }
+
+func writeDOTNode(buf *bytes.Buffer, n DOTNode, opts *DOTOptions, tmpl *template.Template, indent string) error {
+	attrs := make([]string, 0, 2)
+	if opts.RecordShape {
+		attrs = append(attrs, `shape="none"`, "label="+dotRecordLabel(n))
+	} else {
+		var lbl bytes.Buffer
+		if err := tmpl.Execute(&lbl, dotNodeView{n}); err != nil {
+			return err
+		}
+		attrs = append(attrs, fmt.Sprintf("label=%q", lbl.String()))
+	}
+	if color := dotNodeColor(n, opts.NodeColor); color != "" {
+		attrs = append(attrs, "style=filled", fmt.Sprintf("fillcolor=%q", color))
+	}
+	fmt.Fprintf(buf, "%sn%d [%s];\n", indent, n.DOTID(), strings.Join(attrs, ", "))
+	return nil
+}
+
+func writeDOTEdge(buf *bytes.Buffer, e DOTEdge, opts *DOTOptions, tmpl *template.Template) error {
+	var lbl bytes.Buffer
+	if err := tmpl.Execute(&lbl, dotEdgeView{e}); err != nil {
+		return err
+	}
+	attrs := []string{fmt.Sprintf("label=%q", lbl.String())}
+	if style, ok := opts.EdgeStyle[e.DOTType()]; ok {
+		attrs = append(attrs, fmt.Sprintf("style=%q", style))
+	}
+	fmt.Fprintf(buf, "  n%d -> n%d [%s];\n", e.DOTFrom(), e.DOTTo(), strings.Join(attrs, ", "))
+	return nil
+}
+
+func dotClusterKey(n DOTNode, by string) string {
+	if by == "" {
+		return ""
+	}
+	for _, l := range n.DOTLabels() {
+		if l == by {
+			return by
+		}
+	}
+	if v, ok := n.DOTProperties()[by]; ok {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+func dotNodeColor(n DOTNode, colors map[string]string) string {
+	for _, l := range n.DOTLabels() {
+		if c, ok := colors[l]; ok {
+			return c
+		}
+	}
+	return ""
+}
+
+// dotRecordLabel renders a node's properties as an HTML-like Graphviz
+// record table, quoted as Graphviz expects (`<...>`, not `"..."`).
+func dotRecordLabel(n DOTNode) string {
+	props := n.DOTProperties()
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+	for _, l := range n.DOTLabels() {
+		fmt.Fprintf(&sb, `<TR><TD COLSPAN="2"><B>%s</B></TD></TR>`, dotHTMLEscape(l))
+	}
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "<TR><TD>%s</TD><TD>%s</TD></TR>", dotHTMLEscape(k), dotHTMLEscape(fmt.Sprint(props[k])))
+	}
+	sb.WriteString("</TABLE>>")
+	return sb.String()
+}
+
+func dotHTMLEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,34 @@
+package grafeo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"grafeo/wire"
+)
+
+func TestWriteDOTGraph(t *testing.T) {
+	nodes := []DOTNode{
+		wire.Node{ID: 1, Labels: []string{"Person"}, Properties: map[string]any{"name": "Ann"}},
+		wire.Node{ID: 2, Labels: []string{"Person"}, Properties: map[string]any{"name": "Bob"}},
+	}
+	edges := []DOTEdge{
+		wire.Relationship{ID: 1, Type: "KNOWS", StartNodeID: 1, EndNodeID: 2},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDOTGraph(&buf, nodes, edges, nil); err != nil {
+		t.Fatalf("WriteDOTGraph: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "digraph grafeo {") {
+		t.Fatalf("missing digraph header: %q", out)
+	}
+	if !strings.Contains(out, "n1 -> n2") {
+		t.Fatalf("missing edge n1 -> n2: %q", out)
+	}
+	if !strings.Contains(out, "Person") {
+		t.Fatalf("missing node label: %q", out)
+	}
+}
@@ -0,0 +1,58 @@
+// Package driver defines the interface grafeo.DB uses to reach a backend
+// and the registry drivers install themselves into, the same split
+// database/sql keeps between itself and its driver packages: application
+// code imports grafeo (and, for side effects, a driver package such as
+// grafeo or grafeo/memgraph), never this package directly.
+package driver
+
+import (
+	"context"
+
+	"grafeo/cypher"
+)
+
+// Driver opens connections to one kind of backend, identified by the
+// name it is registered under (e.g. "bolt", "memory").
+type Driver interface {
+	Open(dsn string) (Conn, error)
+}
+
+// TxOptions configures a transaction started with Conn.Begin.
+type TxOptions struct {
+	ReadOnly  bool
+	Bookmarks []string
+}
+
+// Conn is one connection to a backend: it can run queries directly or
+// inside an explicit transaction. Its Execute method mirrors
+// grafeo.Executor's but returns driver.Rows rather than grafeo.Rows, so a
+// grafeo.Executor needs a wrapper type to implement Conn; since the two
+// Rows interfaces have the same methods, that wrapper's Execute can return
+// the Executor's Rows unchanged.
+type Conn interface {
+	// Execute runs an already-parsed query and returns its result rows.
+	Execute(ctx context.Context, q *cypher.Query, params map[string]any) (Rows, error)
+	// Begin starts an explicit transaction on this connection.
+	Begin(ctx context.Context, opts TxOptions) error
+	// Commit commits the transaction started by Begin.
+	Commit(ctx context.Context) error
+	// Rollback rolls back the transaction started by Begin.
+	Rollback(ctx context.Context) error
+	// Ping reports whether the connection is still usable, so DB can
+	// evict it from its idle pool instead of handing out a dead
+	// connection.
+	Ping(ctx context.Context) error
+	// Close releases the connection.
+	Close() error
+}
+
+// Rows is the iterator a Conn returns for a query's result set. It has
+// the same shape as grafeo.Rows (and database/sql.Rows), so values
+// satisfying one satisfy the other with no adapter needed.
+type Rows interface {
+	Columns() []string
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close() error
+}
@@ -0,0 +1,42 @@
+package driver
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Driver{}
+)
+
+// Register makes a Driver available under name for grafeo.Open. It is
+// meant to be called from an init() function, the same convention
+// database/sql drivers use; registering the same name twice panics.
+func Register(name string, d Driver) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("grafeo/driver: Register called twice for driver %q", name))
+	}
+	registry[name] = d
+}
+
+// Get returns the driver registered under name, if any.
+func Get(name string) (Driver, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	d, ok := registry[name]
+	return d, ok
+}
+
+// Names returns every currently registered driver name, for diagnostics.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,71 @@
+package grafeo
+
+import (
+	"context"
+
+	"grafeo/cypher"
+)
+
+// Executor runs a parsed Cypher query against a graph backend, remote or
+// embedded. Client.Query uses an Executor to carry out the query it parses;
+// grafeo/memgraph.Graph implements this interface directly so the same
+// parser and AST can drive an in-process engine with no server involved.
+type Executor interface {
+	Execute(ctx context.Context, q *cypher.Query, params map[string]any) (Rows, error)
+}
+
+// Rows is the iterator a backend returns for a query's result set. It is
+// deliberately shaped like database/sql.Rows so callers familiar with that
+// package feel at home.
+type Rows interface {
+	// Columns returns the RETURN projection's column names, in order.
+	Columns() []string
+	// Next advances to the next row, returning false when the result set
+	// is exhausted or an error occurred.
+	Next() bool
+	// Scan copies the current row's columns into dest, in Columns() order.
+	Scan(dest ...any) error
+	// Err returns the first error encountered while iterating, if any.
+	Err() error
+	// Close releases any resources held by the result set.
+	Close() error
+}
+
+// Result is the outcome of a Client.Query call.
+type Result struct {
+	rows Rows
+}
+
+// NewResult wraps an already-produced Rows as a Result, for callers
+// outside this package (e.g. grafeo/httpsrv) that have a Rows from their
+// own Executor but no Client or DB to produce a Result for them.
+func NewResult(rows Rows) *Result {
+	return &Result{rows: rows}
+}
+
+// Columns returns the RETURN projection's column names, in order.
+func (r *Result) Columns() []string {
+	return r.rows.Columns()
+}
+
+// Next advances to the next row. Callers must call Next before the first
+// Scan, mirroring database/sql.Rows.
+func (r *Result) Next() bool {
+	return r.rows.Next()
+}
+
+// Scan copies the current row's columns into dest, in Columns() order.
+func (r *Result) Scan(dest ...any) error {
+	return r.rows.Scan(dest...)
+}
+
+// Err returns the first error encountered while iterating, if any.
+func (r *Result) Err() error {
+	return r.rows.Err()
+}
+
+// Close releases any resources held by the Result. It is safe to call
+// multiple times.
+func (r *Result) Close() error {
+	return r.rows.Close()
+}
@@ -1,10 +1,16 @@
 // Package grafeo provides Go bindings for the Grafeo graph database.
 //
-// Pre-alpha - bindings are under development.
+// Pre-alpha - bindings are under development. The Client type can run a
+// Cypher-compatible subset of queries (MATCH, CREATE, MERGE, SET, DELETE,
+// RETURN) against a remote Grafeo server; see Client.Query. Open provides
+// a pooled, database/sql-style DB on top of any grafeo/driver.Driver,
+// including the built-in "bolt" driver and grafeo/memgraph's "memory"
+// driver.
 // See https://grafeo.dev for current status.
 package grafeo
 
 import "errors"
 
-// ErrNotImplemented is returned when calling any function in this pre-alpha package.
+// ErrNotImplemented is an exported sentinel error; no function in this
+// package returns it.
 var ErrNotImplemented = errors.New("grafeo-go is pre-alpha and not yet implemented; see https://grafeo.dev for status")
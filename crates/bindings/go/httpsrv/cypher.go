@@ -0,0 +1,62 @@
+package httpsrv
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// handleCypher implements POST /cypher: a form-encoded "query" field and a
+// "params" field holding JSON-encoded parameters, exactly what
+// Client.executeHTTP sends, so a *grafeo.Client given this server's
+// address can talk to it with no other transport involved. The response
+// is JSON: {"columns": [...], "rows": [[...], ...]}.
+func (s *Server) handleCypher(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "grafeo: POST only", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		httpError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	query := r.FormValue("query")
+	var params map[string]any
+	if raw := r.FormValue("params"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &params); err != nil {
+			httpError(w, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	rows, err := s.run(r.Context(), query, params)
+	if err != nil {
+		httpError(w, err, http.StatusBadRequest)
+		return
+	}
+	defer rows.Close()
+
+	cols := rows.Columns()
+	data := make([][]any, 0)
+	for rows.Next() {
+		dest := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range dest {
+			ptrs[i] = &dest[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			httpError(w, err, http.StatusInternalServerError)
+			return
+		}
+		data = append(data, dest)
+	}
+	if err := rows.Err(); err != nil {
+		httpError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Columns []string `json:"columns"`
+		Rows    [][]any  `json:"rows"`
+	}{cols, data})
+}
@@ -0,0 +1,39 @@
+package httpsrv_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"grafeo"
+	"grafeo/httpsrv"
+	"grafeo/memgraph"
+)
+
+// TestClientAgainstServer is a regression test for chunk0-6's transport
+// mismatch: handleCypher used to only accept a JSON body, while
+// Client.executeHTTP posts the query/params as a form-encoded request, so
+// every *grafeo.Client query against an httpsrv.Server failed with 400.
+func TestClientAgainstServer(t *testing.T) {
+	g := memgraph.New()
+	ts := httptest.NewServer(httpsrv.New(g))
+	defer ts.Close()
+
+	client := grafeo.NewClient(ts.URL)
+	rows, err := client.Query(context.Background(), "RETURN 1 AS n", nil)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatalf("Next: got no rows, err=%v", rows.Err())
+	}
+	var n int64
+	if err := rows.Scan(&n); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("got n=%d, want 1", n)
+	}
+}
@@ -0,0 +1,97 @@
+package httpsrv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"grafeo"
+)
+
+// handleGraphQL implements POST /graphql: {"query": "..."}  in, a single
+// root field selection out. The resolver discovers a schema from the
+// live graph, translates the whole selection set into one Cypher MATCH
+// traversal (see buildQuery), and runs it through s.exec - so a nested
+// selection costs one query, not one per field.
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "grafeo: POST only", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.graph == nil {
+		http.Error(w, "grafeo: /graphql requires an embedded *memgraph.Graph backend for schema introspection", http.StatusNotImplemented)
+		return
+	}
+
+	var req struct {
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	root, err := parseGraphQL(req.Query)
+	if err != nil {
+		writeGraphQLError(w, err)
+		return
+	}
+
+	query, params, chain, err := buildQuery(root, s.discoverSchema())
+	if err != nil {
+		writeGraphQLError(w, err)
+		return
+	}
+
+	rows, err := s.run(r.Context(), query, params)
+	if err != nil {
+		writeGraphQLError(w, err)
+		return
+	}
+	defer rows.Close()
+
+	var objs []map[string]any
+	for rows.Next() {
+		dest := make([]any, len(chain))
+		ptrs := make([]any, len(chain))
+		for i := range dest {
+			ptrs[i] = &dest[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			writeGraphQLError(w, err)
+			return
+		}
+		nodes := make([]grafeo.DOTNode, len(dest))
+		for i, v := range dest {
+			dn, ok := v.(grafeo.DOTNode)
+			if !ok {
+				writeGraphQLError(w, fmt.Errorf("column %d is not a node", i))
+				return
+			}
+			nodes[i] = dn
+		}
+		objs = append(objs, assembleRow(chain, nodes))
+	}
+	if err := rows.Err(); err != nil {
+		writeGraphQLError(w, err)
+		return
+	}
+
+	objs, err = applyPaging(objs, root.Args)
+	if err != nil {
+		writeGraphQLError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": map[string]any{root.Name: objs},
+	})
+}
+
+// writeGraphQLError reports err the way GraphQL HTTP servers conventionally
+// do: status 200 with an "errors" array, rather than a 4xx/5xx status.
+func writeGraphQLError(w http.ResponseWriter, err error) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"errors": []map[string]any{{"message": err.Error()}},
+	})
+}
@@ -0,0 +1,248 @@
+package httpsrv
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// gqlField is one selected field in a parsed GraphQL query: Name is
+// either a root type/label name or a nested relationship field name, Args
+// holds its argument literals, and Selections holds any nested fields.
+type gqlField struct {
+	Name       string
+	Args       map[string]any
+	Selections []*gqlField
+}
+
+// parseGraphQL parses a single request's worth of GraphQL query text. It
+// supports only the subset httpsrv's resolver needs: one root field (an
+// optional leading "query" keyword and operation name are skipped), field
+// arguments whose values are string, number, boolean, null, or object
+// literals, and no GraphQL variables ($-prefixed values) - argument
+// values must be written out in the query text.
+func parseGraphQL(src string) (*gqlField, error) {
+	p := &gqlParser{src: []rune(src)}
+	p.skipSpace()
+	if p.consumeIdentExact("query") {
+		p.skipSpace()
+		for p.pos < len(p.src) && p.src[p.pos] != '{' {
+			p.pos++
+		}
+		p.skipSpace()
+	}
+	if !p.consumeRune('{') {
+		return nil, fmt.Errorf("graphql: expected '{' to start the query")
+	}
+	p.skipSpace()
+	root, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.consumeRune('}') {
+		return nil, fmt.Errorf("graphql: expected '}' to close the query")
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("graphql: only a single root field is supported per request")
+	}
+	return root, nil
+}
+
+type gqlParser struct {
+	src []rune
+	pos int
+}
+
+func (p *gqlParser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\n', '\r', ',':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *gqlParser) consumeRune(r rune) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == r {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *gqlParser) consumeIdent() string {
+	start := p.pos
+	for p.pos < len(p.src) && isIdentRune(p.src[p.pos]) {
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
+
+func (p *gqlParser) consumeIdentExact(want string) bool {
+	save := p.pos
+	if p.consumeIdent() == want {
+		return true
+	}
+	p.pos = save
+	return false
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func (p *gqlParser) parseField() (*gqlField, error) {
+	p.skipSpace()
+	name := p.consumeIdent()
+	if name == "" {
+		return nil, fmt.Errorf("graphql: expected a field name at position %d", p.pos)
+	}
+	f := &gqlField{Name: name}
+
+	p.skipSpace()
+	if p.pos < len(p.src) && p.src[p.pos] == '(' {
+		args, err := p.parseArgs()
+		if err != nil {
+			return nil, err
+		}
+		f.Args = args
+		p.skipSpace()
+	}
+
+	if p.pos < len(p.src) && p.src[p.pos] == '{' {
+		p.pos++
+		p.skipSpace()
+		for p.pos < len(p.src) && p.src[p.pos] != '}' {
+			sub, err := p.parseField()
+			if err != nil {
+				return nil, err
+			}
+			f.Selections = append(f.Selections, sub)
+			p.skipSpace()
+		}
+		if !p.consumeRune('}') {
+			return nil, fmt.Errorf("graphql: expected '}' to close the selection set on %q", name)
+		}
+	}
+	return f, nil
+}
+
+func (p *gqlParser) parseArgs() (map[string]any, error) {
+	p.pos++ // '('
+	args := map[string]any{}
+	p.skipSpace()
+	for p.pos < len(p.src) && p.src[p.pos] != ')' {
+		name := p.consumeIdent()
+		if name == "" {
+			return nil, fmt.Errorf("graphql: expected an argument name at position %d", p.pos)
+		}
+		p.skipSpace()
+		if !p.consumeRune(':') {
+			return nil, fmt.Errorf("graphql: expected ':' after argument %q", name)
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		args[name] = v
+		p.skipSpace()
+	}
+	if !p.consumeRune(')') {
+		return nil, fmt.Errorf("graphql: expected ')' to close arguments")
+	}
+	return args, nil
+}
+
+func (p *gqlParser) parseValue() (any, error) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return nil, fmt.Errorf("graphql: unexpected end of input in a value")
+	}
+	switch c := p.src[p.pos]; {
+	case c == '"':
+		return p.parseString()
+	case c == '{':
+		return p.parseObject()
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	default:
+		ident := p.consumeIdent()
+		switch ident {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		case "":
+			return nil, fmt.Errorf("graphql: unexpected character %q in a value", string(c))
+		default:
+			return ident, nil
+		}
+	}
+}
+
+func (p *gqlParser) parseString() (string, error) {
+	p.pos++ // opening '"'
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] != '"' {
+		if p.src[p.pos] == '\\' {
+			p.pos++
+		}
+		p.pos++
+	}
+	if p.pos >= len(p.src) {
+		return "", fmt.Errorf("graphql: unterminated string")
+	}
+	s := string(p.src[start:p.pos])
+	p.pos++ // closing '"'
+	return s, nil
+}
+
+func (p *gqlParser) parseNumber() (any, error) {
+	start := p.pos
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	isFloat := false
+	for p.pos < len(p.src) && (p.src[p.pos] >= '0' && p.src[p.pos] <= '9' || p.src[p.pos] == '.') {
+		if p.src[p.pos] == '.' {
+			isFloat = true
+		}
+		p.pos++
+	}
+	text := string(p.src[start:p.pos])
+	if isFloat {
+		return strconv.ParseFloat(text, 64)
+	}
+	return strconv.ParseInt(text, 10, 64)
+}
+
+func (p *gqlParser) parseObject() (map[string]any, error) {
+	p.pos++ // '{'
+	obj := map[string]any{}
+	p.skipSpace()
+	for p.pos < len(p.src) && p.src[p.pos] != '}' {
+		key := p.consumeIdent()
+		if key == "" {
+			return nil, fmt.Errorf("graphql: expected an object field name at position %d", p.pos)
+		}
+		p.skipSpace()
+		if !p.consumeRune(':') {
+			return nil, fmt.Errorf("graphql: expected ':' after object field %q", key)
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		obj[key] = v
+		p.skipSpace()
+	}
+	if !p.consumeRune('}') {
+		return nil, fmt.Errorf("graphql: expected '}' to close an object")
+	}
+	return obj, nil
+}
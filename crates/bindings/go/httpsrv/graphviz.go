@@ -0,0 +1,29 @@
+package httpsrv
+
+import (
+	"net/http"
+
+	"grafeo"
+)
+
+// handleGraphviz implements GET /graphviz?q=..., rendering the query's
+// result as Graphviz DOT via grafeo.WriteDOT; a `MATCH ... RETURN path`
+// query can be piped straight to `dot -Tsvg`.
+func (s *Server) handleGraphviz(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query().Get("q")
+	if q == "" {
+		http.Error(w, "grafeo: missing q query parameter", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := s.run(r.Context(), q, nil)
+	if err != nil {
+		httpError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/vnd.graphviz")
+	if err := grafeo.WriteDOT(w, grafeo.NewResult(rows), &grafeo.DOTOptions{}); err != nil {
+		httpError(w, err, http.StatusInternalServerError)
+	}
+}
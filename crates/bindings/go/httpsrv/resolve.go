@@ -0,0 +1,156 @@
+package httpsrv
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"grafeo"
+)
+
+// buildQuery translates root's selection set into a single linear Cypher
+// MATCH pattern: each relationship-valued selection extends the chain by
+// one hop, so a query nested several levels deep becomes one traversal
+// instead of one round trip per level. Only one relationship selection
+// per level is supported, since this Cypher subset has no branching
+// patterns (MATCH (a)-[]-(b), (a)-[]-(c) isn't expressible). It returns
+// the query text, its $-parameters, and the selected field at each level
+// of root's selection chain, in chain order; level i is bound to pattern
+// variable n<i> and is the query's i-th RETURN column.
+func buildQuery(root *gqlField, sch *gqlSchema) (query string, params map[string]any, chain []*gqlField, err error) {
+	params = map[string]any{}
+	var pattern strings.Builder
+	var whereParts []string
+	paramIdx := 0
+
+	addWhere := func(varName string, where map[string]any) {
+		keys := make([]string, 0, len(where))
+		for k := range where {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			paramKey := fmt.Sprintf("p%d", paramIdx)
+			paramIdx++
+			params[paramKey] = where[k]
+			whereParts = append(whereParts, fmt.Sprintf("%s.%s = $%s", varName, k, paramKey))
+		}
+	}
+
+	label := root.Name
+	varName := "n0"
+	chain = append(chain, root)
+	fmt.Fprintf(&pattern, "(%s:%s)", varName, label)
+	if where, ok := root.Args["where"].(map[string]any); ok {
+		addWhere(varName, where)
+	}
+
+	field := root
+	for {
+		var next *gqlField
+		var rf gqlRelField
+		found := false
+		for _, sel := range field.Selections {
+			candidate, ok := sch.relFields[label+"."+sel.Name]
+			if !ok {
+				continue // a scalar leaf field, not a traversal hop
+			}
+			if found {
+				return "", nil, nil, fmt.Errorf("graphql: field %q selects more than one relationship (%q and %q); only a single traversal chain is supported", field.Name, next.Name, sel.Name)
+			}
+			found = true
+			next, rf = sel, candidate
+		}
+		if !found {
+			break
+		}
+
+		childVar := fmt.Sprintf("n%d", len(chain))
+		fmt.Fprintf(&pattern, "-[:%s]->(%s:%s)", rf.Type, childVar, rf.TargetLabel)
+		chain = append(chain, next)
+		if where, ok := next.Args["where"].(map[string]any); ok {
+			addWhere(childVar, where)
+		}
+
+		label, varName, field = rf.TargetLabel, childVar, next
+	}
+
+	var q strings.Builder
+	fmt.Fprintf(&q, "MATCH %s", pattern.String())
+	if len(whereParts) > 0 {
+		fmt.Fprintf(&q, " WHERE %s", strings.Join(whereParts, " AND "))
+	}
+	q.WriteString(" RETURN ")
+	for i := range chain {
+		if i > 0 {
+			q.WriteString(", ")
+		}
+		fmt.Fprintf(&q, "n%d", i)
+	}
+	return q.String(), params, chain, nil
+}
+
+// assembleRow builds the nested JSON object for one result row: fields
+// and nodes are parallel, chain-ordered slices (see buildQuery), so
+// fields[0]/nodes[0] is the root, fields[1]/nodes[1] its traversed
+// relationship selection if any, and so on.
+func assembleRow(fields []*gqlField, nodes []grafeo.DOTNode) map[string]any {
+	field, node := fields[0], nodes[0]
+	props := node.DOTProperties()
+	obj := make(map[string]any, len(field.Selections))
+	for _, sel := range field.Selections {
+		if len(fields) > 1 && fields[1] == sel {
+			obj[sel.Name] = assembleRow(fields[1:], nodes[1:])
+			continue
+		}
+		obj[sel.Name] = props[sel.Name]
+	}
+	return obj
+}
+
+// applyPaging applies the root field's orderBy, first/after and limit
+// arguments to an assembled result list. Cypher has no ORDER BY or LIMIT
+// in this subset, so these are resolved here instead of pushed into the
+// query; after is a plain row-index cursor rather than an opaque one, and
+// no pageInfo/endCursor is returned to the caller - a deliberately
+// minimal reference implementation of relay-style pagination.
+func applyPaging(objs []map[string]any, args map[string]any) ([]map[string]any, error) {
+	if orderBy, ok := args["orderBy"].(string); ok {
+		sort.SliceStable(objs, func(i, j int) bool {
+			return fmt.Sprint(objs[i][orderBy]) < fmt.Sprint(objs[j][orderBy])
+		})
+	}
+	if afterStr, ok := args["after"].(string); ok {
+		idx, err := strconv.Atoi(afterStr)
+		if err != nil {
+			return nil, fmt.Errorf("graphql: invalid after cursor %q", afterStr)
+		}
+		if idx+1 < len(objs) {
+			objs = objs[idx+1:]
+		} else {
+			objs = nil
+		}
+	}
+	if n, ok := asInt(args["first"]); ok {
+		if n < len(objs) {
+			objs = objs[:n]
+		}
+	} else if n, ok := asInt(args["limit"]); ok {
+		if n < len(objs) {
+			objs = objs[:n]
+		}
+	}
+	return objs, nil
+}
+
+func asInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
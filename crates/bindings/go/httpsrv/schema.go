@@ -0,0 +1,66 @@
+package httpsrv
+
+import "strings"
+
+// gqlRelField describes one relationship-valued GraphQL field: the
+// Cypher relationship type it traverses, and the label found on the
+// nodes at the other end.
+type gqlRelField struct {
+	Type        string
+	TargetLabel string
+}
+
+// gqlSchema is discovered fresh from the graph's current contents for
+// every /graphql request, rather than cached, since CREATE/MERGE/DELETE
+// can change the set of labels, properties and relationship types
+// between requests.
+type gqlSchema struct {
+	// properties maps a label to the property names observed on nodes
+	// carrying it. It is currently unused by the resolver (any property
+	// name is accepted and simply returns null if absent) but kept
+	// around for a future schema/introspection endpoint.
+	properties map[string]map[string]bool
+	// relFields maps "Label.fieldName" (fieldName is the relationship
+	// type, lowercased) to the relationship it traverses.
+	relFields map[string]gqlRelField
+}
+
+// discoverSchema walks s.graph directly rather than going through Cypher,
+// since this Cypher subset has no way to RETURN a relationship's type or
+// iterate distinct labels.
+func (s *Server) discoverSchema() *gqlSchema {
+	sch := &gqlSchema{
+		properties: map[string]map[string]bool{},
+		relFields:  map[string]gqlRelField{},
+	}
+
+	labelsByID := map[int64][]string{}
+	for _, n := range s.graph.Nodes() {
+		labelsByID[int64(n.ID)] = n.Labels
+		for _, label := range n.Labels {
+			fields := sch.properties[label]
+			if fields == nil {
+				fields = map[string]bool{}
+				sch.properties[label] = fields
+			}
+			for prop := range n.Properties {
+				fields[prop] = true
+			}
+		}
+	}
+
+	for _, e := range s.graph.Edges() {
+		fromLabels, fok := labelsByID[int64(e.From)]
+		toLabels, tok := labelsByID[int64(e.To)]
+		if !fok || !tok {
+			continue
+		}
+		field := strings.ToLower(e.Type)
+		for _, label := range fromLabels {
+			for _, targetLabel := range toLabels {
+				sch.relFields[label+"."+field] = gqlRelField{Type: e.Type, TargetLabel: targetLabel}
+			}
+		}
+	}
+	return sch
+}
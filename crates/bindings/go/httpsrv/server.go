@@ -0,0 +1,67 @@
+// Package httpsrv exposes a grafeo.Executor over HTTP: POST /cypher runs a
+// query and returns its rows as JSON, GET /graphviz renders a query's
+// result as Graphviz DOT, and POST /graphql answers a small GraphQL
+// subset backed by a schema discovered from the graph's current labels
+// and properties. /cypher and /graphviz work transparently against any
+// Executor, remote grafeo.Client included; /graphql needs direct
+// introspection and so requires an embedded *grafeo/memgraph.Graph.
+package httpsrv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"grafeo"
+	"grafeo/cypher"
+	"grafeo/memgraph"
+)
+
+// Server adapts a grafeo.Executor to http.Handler. The zero value is not
+// usable; construct one with New.
+type Server struct {
+	exec  grafeo.Executor
+	graph *memgraph.Graph
+	mux   *http.ServeMux
+}
+
+// New returns a Server backed by exec. If exec is a *memgraph.Graph,
+// /graphql is also enabled, since it needs to introspect the graph's
+// labels, properties and relationship types directly.
+func New(exec grafeo.Executor) *Server {
+	s := &Server{exec: exec}
+	if g, ok := exec.(*memgraph.Graph); ok {
+		s.graph = g
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cypher", s.handleCypher)
+	mux.HandleFunc("/graphql", s.handleGraphQL)
+	mux.HandleFunc("/graphviz", s.handleGraphviz)
+	s.mux = mux
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// run parses and executes a Cypher-subset query against s.exec.
+func (s *Server) run(ctx context.Context, query string, params map[string]any) (grafeo.Rows, error) {
+	q, err := cypher.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	return s.exec.Execute(ctx, q, params)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func httpError(w http.ResponseWriter, err error, status int) {
+	http.Error(w, "grafeo: "+err.Error(), status)
+}
@@ -0,0 +1,25 @@
+package memgraph
+
+import (
+	"io"
+
+	"grafeo"
+)
+
+// WriteDOT renders the whole graph as Graphviz DOT via grafeo.WriteDOTGraph,
+// the same renderer grafeo.WriteDOT uses for remote query results.
+func (g *Graph) WriteDOT(w io.Writer, opts *grafeo.DOTOptions) error {
+	nodes := g.Nodes()
+	dotNodes := make([]grafeo.DOTNode, len(nodes))
+	for i, n := range nodes {
+		dotNodes[i] = n
+	}
+
+	edges := g.Edges()
+	dotEdges := make([]grafeo.DOTEdge, len(edges))
+	for i, e := range edges {
+		dotEdges[i] = e
+	}
+
+	return grafeo.WriteDOTGraph(w, dotNodes, dotEdges, opts)
+}
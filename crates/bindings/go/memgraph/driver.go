@@ -0,0 +1,64 @@
+package memgraph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"grafeo/cypher"
+	"grafeo/driver"
+)
+
+func init() {
+	driver.Register("memory", memoryDriver{})
+}
+
+var (
+	sharedMu     sync.Mutex
+	sharedGraphs = map[string]*Graph{}
+)
+
+// memoryDriver opens connections onto an embedded, in-process Graph: a
+// non-empty dsn names a graph shared by every Open call with that same
+// dsn, so a pooled grafeo.DB's connections all see the same data; an
+// empty dsn ("grafeo.Open(\"memory\", \"\")") gets a fresh, unshared
+// graph each time, for isolated tests.
+type memoryDriver struct{}
+
+func (memoryDriver) Open(dsn string) (driver.Conn, error) {
+	if dsn == "" {
+		return &memoryConn{g: New()}, nil
+	}
+	sharedMu.Lock()
+	g, ok := sharedGraphs[dsn]
+	if !ok {
+		g = New()
+		sharedGraphs[dsn] = g
+	}
+	sharedMu.Unlock()
+	return &memoryConn{g: g}, nil
+}
+
+// memoryConn adapts a *Graph to driver.Conn. The embedded engine applies
+// writes as they run rather than buffering them, so Begin/Commit are
+// no-ops and Rollback, which would require undoing already-applied
+// writes, is reported as unsupported.
+type memoryConn struct {
+	g *Graph
+}
+
+func (c *memoryConn) Execute(ctx context.Context, q *cypher.Query, params map[string]any) (driver.Rows, error) {
+	return c.g.Execute(ctx, q, params)
+}
+
+func (c *memoryConn) Begin(ctx context.Context, opts driver.TxOptions) error { return nil }
+
+func (c *memoryConn) Commit(ctx context.Context) error { return nil }
+
+func (c *memoryConn) Rollback(ctx context.Context) error {
+	return fmt.Errorf("memgraph: Rollback is not supported; writes are applied immediately")
+}
+
+func (c *memoryConn) Ping(ctx context.Context) error { return nil }
+
+func (c *memoryConn) Close() error { return nil }
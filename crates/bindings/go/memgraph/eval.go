@@ -0,0 +1,191 @@
+package memgraph
+
+import (
+	"fmt"
+	"reflect"
+
+	"grafeo/cypher"
+)
+
+// evalExpr evaluates a cypher.Expr against the current row's variable
+// bindings (ctx) and the query's parameters. PropertyAccess and VarRef
+// look the variable up in ctx; ctx may be nil when evaluating expressions
+// that cannot reference pattern variables (e.g. a pattern's own property
+// map, evaluated before any node is bound). g guards the PropertyAccess
+// case's read of a live Node's Properties map with the graph's own lock,
+// since that map may be mutated concurrently by a SET on another query.
+func evalExpr(g *Graph, e cypher.Expr, params map[string]any, ctx map[string]*Node) (any, error) {
+	switch e := e.(type) {
+	case *cypher.Literal:
+		return e.Value, nil
+	case *cypher.Param:
+		v, ok := params[e.Name]
+		if !ok {
+			return nil, fmt.Errorf("memgraph: parameter $%s was not supplied", e.Name)
+		}
+		return v, nil
+	case *cypher.PropertyAccess:
+		n, ok := ctx[e.Variable]
+		if !ok {
+			return nil, fmt.Errorf("memgraph: unbound variable %q", e.Variable)
+		}
+		return g.nodeProperty(n, e.Property), nil
+	case *cypher.VarRef:
+		n, ok := ctx[e.Variable]
+		if !ok {
+			return nil, fmt.Errorf("memgraph: unbound variable %q", e.Variable)
+		}
+		return n, nil
+	case *cypher.BinaryExpr:
+		return evalBinary(g, e, params, ctx)
+	default:
+		return nil, fmt.Errorf("memgraph: unsupported expression %T", e)
+	}
+}
+
+func evalBinary(g *Graph, e *cypher.BinaryExpr, params map[string]any, ctx map[string]*Node) (any, error) {
+	switch e.Op {
+	case "AND", "OR":
+		left, err := evalBool(g, e.Left, params, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if e.Op == "AND" && !left {
+			return false, nil
+		}
+		if e.Op == "OR" && left {
+			return true, nil
+		}
+		return evalBool(g, e.Right, params, ctx)
+	default:
+		left, err := evalExpr(g, e.Left, params, ctx)
+		if err != nil {
+			return nil, err
+		}
+		right, err := evalExpr(g, e.Right, params, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return compare(e.Op, left, right)
+	}
+}
+
+func evalBool(g *Graph, e cypher.Expr, params map[string]any, ctx map[string]*Node) (bool, error) {
+	v, err := evalExpr(g, e, params, ctx)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("memgraph: expected boolean expression, got %T", v)
+	}
+	return b, nil
+}
+
+func compare(op string, left, right any) (bool, error) {
+	lf, lok := asFloat(left)
+	rf, rok := asFloat(right)
+	if lok && rok {
+		switch op {
+		case "=":
+			return lf == rf, nil
+		case "<>":
+			return lf != rf, nil
+		case "<":
+			return lf < rf, nil
+		case "<=":
+			return lf <= rf, nil
+		case ">":
+			return lf > rf, nil
+		case ">=":
+			return lf >= rf, nil
+		}
+	}
+
+	switch op {
+	case "=":
+		return deepEqual(left, right), nil
+	case "<>":
+		return !deepEqual(left, right), nil
+	default:
+		ls, lok := left.(string)
+		rs, rok := right.(string)
+		if !lok || !rok {
+			return false, fmt.Errorf("memgraph: cannot compare %T %s %T", left, op, right)
+		}
+		switch op {
+		case "<":
+			return ls < rs, nil
+		case "<=":
+			return ls <= rs, nil
+		case ">":
+			return ls > rs, nil
+		case ">=":
+			return ls >= rs, nil
+		}
+	}
+	return false, fmt.Errorf("memgraph: unsupported operator %q", op)
+}
+
+func asFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+func valuesEqual(a, b any) bool {
+	if af, aok := asFloat(a); aok {
+		if bf, bok := asFloat(b); bok {
+			return af == bf
+		}
+	}
+	return deepEqual(a, b)
+}
+
+// deepEqual compares two property/parameter values for equality without
+// risking the runtime panic Go's == gives on uncomparable dynamic types
+// (slices, maps) - legal property and parameter values throughout this
+// package.
+func deepEqual(a, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// propsMatcher returns a propEvaluator bound to g and the given query
+// parameters, used by the VF2 matcher to test a pattern's property map
+// against a candidate node or edge's stored properties.
+func propsMatcher(g *Graph, params map[string]any) propEvaluator {
+	return func(pattern map[string]cypher.Expr, actual map[string]any) (bool, error) {
+		for key, expr := range pattern {
+			want, err := evalExpr(g, expr, params, nil)
+			if err != nil {
+				return false, err
+			}
+			got, ok := actual[key]
+			if !ok || !valuesEqual(want, got) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
+
+// materializeProps evaluates a pattern's property map into concrete values,
+// used when CREATE/MERGE need to write a node or edge's initial properties.
+func materializeProps(g *Graph, pattern map[string]cypher.Expr, params map[string]any, ctx map[string]*Node) (map[string]any, error) {
+	out := map[string]any{}
+	for key, expr := range pattern {
+		v, err := evalExpr(g, expr, params, ctx)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = v
+	}
+	return out, nil
+}
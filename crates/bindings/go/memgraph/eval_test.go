@@ -0,0 +1,35 @@
+package memgraph
+
+import "testing"
+
+func TestCompareListEquality(t *testing.T) {
+	left := []any{"a", "b"}
+	right := []any{"a", "b"}
+
+	eq, err := compare("=", left, right)
+	if err != nil {
+		t.Fatalf("compare(=): %v", err)
+	}
+	if !eq {
+		t.Fatalf("compare(=): got false, want true for equal lists")
+	}
+
+	neq, err := compare("<>", left, []any{"a", "c"})
+	if err != nil {
+		t.Fatalf("compare(<>): %v", err)
+	}
+	if !neq {
+		t.Fatalf("compare(<>): got false, want true for differing lists")
+	}
+}
+
+func TestValuesEqualMapValued(t *testing.T) {
+	a := map[string]any{"x": int64(1)}
+	b := map[string]any{"x": int64(1)}
+	if !valuesEqual(a, b) {
+		t.Fatalf("valuesEqual: got false, want true for equal maps")
+	}
+	if valuesEqual(a, map[string]any{"x": int64(2)}) {
+		t.Fatalf("valuesEqual: got true, want false for differing maps")
+	}
+}
@@ -0,0 +1,217 @@
+package memgraph
+
+import (
+	"context"
+	"fmt"
+
+	"grafeo"
+	"grafeo/cypher"
+)
+
+// Execute implements grafeo.Executor directly against the in-memory graph,
+// so a *Graph can be used anywhere a remote grafeo.Client would be: the
+// MATCH pattern is run through the VF2 matcher in vf2.go, WHERE filters the
+// resulting mappings, and CREATE/MERGE/SET/DELETE apply per matched row
+// before RETURN projects the final columns.
+func (g *Graph) Execute(ctx context.Context, q *cypher.Query, params map[string]any) (grafeo.Rows, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var rowContexts []map[string]*Node
+	switch len(q.Matches) {
+	case 0:
+		rowContexts = []map[string]*Node{{}}
+	case 1:
+		match := q.Matches[0]
+		pg := buildPatternGraph(match.Pattern)
+		mt := newMatcher(pg, g, propsMatcher(g, params))
+		err := mt.Run(func(mapping Mapping) error {
+			rc := map[string]*Node{}
+			for v, id := range mapping.Nodes {
+				n, ok := g.Node(id)
+				if !ok {
+					return fmt.Errorf("memgraph: matched node %d no longer exists", id)
+				}
+				rc[v] = n
+			}
+			if match.Where != nil {
+				ok, err := evalBool(g, match.Where, params, rc)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return nil
+				}
+			}
+			rowContexts = append(rowContexts, rc)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("memgraph: only a single MATCH clause per query is supported")
+	}
+
+	for _, rc := range rowContexts {
+		for _, cr := range q.Creates {
+			if err := g.applyCreate(cr.Pattern, params, rc); err != nil {
+				return nil, err
+			}
+		}
+		for _, mg := range q.Merges {
+			if err := g.applyMerge(mg.Pattern, params, rc); err != nil {
+				return nil, err
+			}
+		}
+		for _, s := range q.Sets {
+			if err := g.applySet(s, params, rc); err != nil {
+				return nil, err
+			}
+		}
+		for _, d := range q.Deletes {
+			if err := g.applyDelete(d, rc); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if q.Return == nil {
+		return &staticRows{}, nil
+	}
+
+	cols := make([]string, len(q.Return.Items))
+	for i, item := range q.Return.Items {
+		cols[i] = returnColumnName(item, i)
+	}
+	data := make([][]any, 0, len(rowContexts))
+	for _, rc := range rowContexts {
+		row := make([]any, len(q.Return.Items))
+		for i, item := range q.Return.Items {
+			v, err := evalExpr(g, item.Expr, params, rc)
+			if err != nil {
+				return nil, err
+			}
+			row[i] = v
+		}
+		data = append(data, row)
+	}
+	return &staticRows{columns: cols, data: data, pos: -1}, nil
+}
+
+func returnColumnName(item *cypher.ReturnItem, idx int) string {
+	if item.Alias != "" {
+		return item.Alias
+	}
+	switch e := item.Expr.(type) {
+	case *cypher.VarRef:
+		return e.Variable
+	case *cypher.PropertyAccess:
+		return e.Variable + "." + e.Property
+	default:
+		return fmt.Sprintf("col%d", idx)
+	}
+}
+
+// applyCreate creates the nodes and relationships described by pat. A node
+// pattern whose variable is already bound in ctx (e.g. from a preceding
+// MATCH) is reused as that endpoint rather than creating a duplicate node.
+func (g *Graph) applyCreate(pat *cypher.Pattern, params map[string]any, ctx map[string]*Node) error {
+	ids := make([]NodeID, len(pat.Nodes))
+	for i, np := range pat.Nodes {
+		if np.Variable != "" {
+			if existing, ok := ctx[np.Variable]; ok {
+				ids[i] = existing.ID
+				continue
+			}
+		}
+		props, err := materializeProps(g, np.Properties, params, ctx)
+		if err != nil {
+			return err
+		}
+		n := g.AddNode(np.Labels, props)
+		ids[i] = n.ID
+		if np.Variable != "" {
+			ctx[np.Variable] = n
+		}
+	}
+
+	for i, rel := range pat.Rels {
+		from, to := ids[i], ids[i+1]
+		if rel.Direction == cypher.DirIncoming {
+			from, to = to, from
+		}
+		relType := ""
+		if len(rel.Types) > 0 {
+			relType = rel.Types[0]
+		}
+		props, err := materializeProps(g, rel.Properties, params, ctx)
+		if err != nil {
+			return err
+		}
+		if _, err := g.AddEdge(from, to, relType, props); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyMerge matches pat against the whole graph and binds the first
+// result into ctx, falling back to creating it (CREATE semantics) if no
+// match exists.
+func (g *Graph) applyMerge(pat *cypher.Pattern, params map[string]any, ctx map[string]*Node) error {
+	pg := buildPatternGraph(pat)
+	mt := newMatcher(pg, g, propsMatcher(g, params))
+	found := false
+	err := mt.Run(func(mapping Mapping) error {
+		if found {
+			return nil
+		}
+		found = true
+		for v, id := range mapping.Nodes {
+			n, ok := g.Node(id)
+			if !ok {
+				return fmt.Errorf("memgraph: matched node %d no longer exists", id)
+			}
+			ctx[v] = n
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if found {
+		return nil
+	}
+	return g.applyCreate(pat, params, ctx)
+}
+
+func (g *Graph) applySet(s *cypher.SetClause, params map[string]any, ctx map[string]*Node) error {
+	for _, item := range s.Items {
+		n, ok := ctx[item.Variable]
+		if !ok {
+			return fmt.Errorf("memgraph: SET references unbound variable %q", item.Variable)
+		}
+		v, err := evalExpr(g, item.Value, params, ctx)
+		if err != nil {
+			return err
+		}
+		g.setNodeProperty(n, item.Property, v)
+	}
+	return nil
+}
+
+func (g *Graph) applyDelete(d *cypher.DeleteClause, ctx map[string]*Node) error {
+	for _, v := range d.Variables {
+		n, ok := ctx[v]
+		if !ok {
+			return fmt.Errorf("memgraph: DELETE references unbound variable %q", v)
+		}
+		if err := g.RemoveNode(n.ID, d.Detach); err != nil {
+			return err
+		}
+		delete(ctx, v)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package memgraph
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"grafeo/cypher"
+)
+
+func TestExecuteMatchTraversal(t *testing.T) {
+	g := New()
+	a := g.AddNode([]string{"Person"}, map[string]any{"name": "Ann"})
+	b := g.AddNode([]string{"Person"}, map[string]any{"name": "Bob"})
+	c := g.AddNode([]string{"Person"}, map[string]any{"name": "Cara"})
+	if _, err := g.AddEdge(a.ID, b.ID, "KNOWS", nil); err != nil {
+		t.Fatalf("AddEdge a->b: %v", err)
+	}
+	if _, err := g.AddEdge(b.ID, c.ID, "KNOWS", nil); err != nil {
+		t.Fatalf("AddEdge b->c: %v", err)
+	}
+
+	q, err := cypher.Parse("MATCH (a:Person)-[:KNOWS*1..2]->(b:Person) RETURN a, b")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	rows, err := g.Execute(context.Background(), q, nil)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows.Err: %v", err)
+	}
+	// a->b (hop 1), b->c (hop 1), a->b->c (hop 2): three matches.
+	if count != 3 {
+		t.Fatalf("got %d matches, want 3", count)
+	}
+}
+
+// TestExecuteConcurrentSetAndMatch is a regression test (run with -race)
+// for Graph's documented concurrent-use safety: a SET on one goroutine
+// and a MATCH...RETURN reading the same node's properties on another used
+// to race directly on the node's Properties map.
+func TestExecuteConcurrentSetAndMatch(t *testing.T) {
+	g := New()
+	g.AddNode([]string{"Person"}, map[string]any{"visits": int64(0)})
+
+	setQ, err := cypher.Parse("MATCH (p:Person) SET p.visits = 1 RETURN p")
+	if err != nil {
+		t.Fatalf("Parse SET: %v", err)
+	}
+	matchQ, err := cypher.Parse("MATCH (p:Person) RETURN p.visits")
+	if err != nil {
+		t.Fatalf("Parse MATCH: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			rows, err := g.Execute(context.Background(), setQ, nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for rows.Next() {
+			}
+			rows.Close()
+		}()
+		go func() {
+			defer wg.Done()
+			rows, err := g.Execute(context.Background(), matchQ, nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for rows.Next() {
+			}
+			rows.Close()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,292 @@
+// Package memgraph is a pure-Go, in-memory directed labeled property graph.
+// It implements grafeo.Executor directly, so the Cypher parser in
+// grafeo/cypher can drive it without a server: a *Graph is a usable
+// offline/embedded mode for grafeo, and a reference implementation of the
+// remote engine's query semantics.
+package memgraph
+
+import (
+	"fmt"
+	"sync"
+)
+
+// NodeID identifies a node within a single Graph.
+type NodeID uint64
+
+// EdgeID identifies an edge within a single Graph.
+type EdgeID uint64
+
+// Node is a labeled property-graph vertex.
+type Node struct {
+	ID         NodeID
+	Labels     []string
+	Properties map[string]any
+}
+
+// HasLabel reports whether n carries the given label.
+func (n *Node) HasLabel(label string) bool {
+	for _, l := range n.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// DOTID, DOTLabels and DOTProperties satisfy grafeo.DOTNode, so a *Node
+// can be rendered by grafeo.WriteDOTGraph without memgraph depending on
+// the grafeo package for anything but that interface's method names.
+func (n *Node) DOTID() int64                  { return int64(n.ID) }
+func (n *Node) DOTLabels() []string           { return n.Labels }
+func (n *Node) DOTProperties() map[string]any { return n.Properties }
+
+// Edge is a typed, directed property-graph relationship from From to To.
+type Edge struct {
+	ID         EdgeID
+	Type       string
+	From       NodeID
+	To         NodeID
+	Properties map[string]any
+}
+
+// DOTID, DOTType, DOTFrom, DOTTo and DOTProperties satisfy grafeo.DOTEdge.
+func (e *Edge) DOTID() int64                  { return int64(e.ID) }
+func (e *Edge) DOTType() string               { return e.Type }
+func (e *Edge) DOTFrom() int64                { return int64(e.From) }
+func (e *Edge) DOTTo() int64                  { return int64(e.To) }
+func (e *Edge) DOTProperties() map[string]any { return e.Properties }
+
+// Graph is an in-memory directed labeled property graph. The zero value is
+// not usable; construct one with New. A *Graph is safe for concurrent use.
+type Graph struct {
+	mu sync.RWMutex
+
+	nodes map[NodeID]*Node
+	edges map[EdgeID]*Edge
+
+	// adjacency indexes, keyed by node, of edge IDs incident to that node.
+	outEdges map[NodeID][]EdgeID
+	inEdges  map[NodeID][]EdgeID
+
+	nextNodeID NodeID
+	nextEdgeID EdgeID
+}
+
+// New returns an empty Graph.
+func New() *Graph {
+	return &Graph{
+		nodes:    map[NodeID]*Node{},
+		edges:    map[EdgeID]*Edge{},
+		outEdges: map[NodeID][]EdgeID{},
+		inEdges:  map[NodeID][]EdgeID{},
+	}
+}
+
+// AddNode creates a new node with the given labels and properties and
+// returns it. The Properties map is copied.
+func (g *Graph) AddNode(labels []string, properties map[string]any) *Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.nextNodeID++
+	n := &Node{
+		ID:         g.nextNodeID,
+		Labels:     append([]string(nil), labels...),
+		Properties: cloneProps(properties),
+	}
+	g.nodes[n.ID] = n
+	return n
+}
+
+// AddEdge creates a directed, typed edge from -> to and returns it. It
+// returns an error if either endpoint does not exist.
+func (g *Graph) AddEdge(from, to NodeID, edgeType string, properties map[string]any) (*Edge, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.nodes[from]; !ok {
+		return nil, fmt.Errorf("memgraph: node %d does not exist", from)
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return nil, fmt.Errorf("memgraph: node %d does not exist", to)
+	}
+
+	g.nextEdgeID++
+	e := &Edge{
+		ID:         g.nextEdgeID,
+		Type:       edgeType,
+		From:       from,
+		To:         to,
+		Properties: cloneProps(properties),
+	}
+	g.edges[e.ID] = e
+	g.outEdges[from] = append(g.outEdges[from], e.ID)
+	g.inEdges[to] = append(g.inEdges[to], e.ID)
+	return e, nil
+}
+
+// Node returns the node with the given ID, if it exists.
+func (g *Graph) Node(id NodeID) (*Node, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	n, ok := g.nodes[id]
+	return n, ok
+}
+
+// Nodes returns every node in the graph, in unspecified order.
+func (g *Graph) Nodes() []*Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	out := make([]*Node, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		out = append(out, n)
+	}
+	return out
+}
+
+// Edges returns every edge in the graph, in unspecified order.
+func (g *Graph) Edges() []*Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	out := make([]*Edge, 0, len(g.edges))
+	for _, e := range g.edges {
+		out = append(out, e)
+	}
+	return out
+}
+
+// RemoveNode deletes a node. If detach is false, RemoveNode returns an
+// error when the node still has incident edges; if detach is true, those
+// edges are removed first (DETACH DELETE semantics).
+func (g *Graph) RemoveNode(id NodeID, detach bool) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.nodes[id]; !ok {
+		return fmt.Errorf("memgraph: node %d does not exist", id)
+	}
+
+	incident := append(append([]EdgeID(nil), g.outEdges[id]...), g.inEdges[id]...)
+	if len(incident) > 0 && !detach {
+		return fmt.Errorf("memgraph: node %d still has %d incident edge(s); use DETACH DELETE", id, len(incident))
+	}
+	for _, eid := range incident {
+		g.removeEdgeLocked(eid)
+	}
+	delete(g.nodes, id)
+	delete(g.outEdges, id)
+	delete(g.inEdges, id)
+	return nil
+}
+
+// RemoveEdge deletes a single edge.
+func (g *Graph) RemoveEdge(id EdgeID) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.edges[id]; !ok {
+		return fmt.Errorf("memgraph: edge %d does not exist", id)
+	}
+	g.removeEdgeLocked(id)
+	return nil
+}
+
+func (g *Graph) removeEdgeLocked(id EdgeID) {
+	e, ok := g.edges[id]
+	if !ok {
+		return
+	}
+	g.outEdges[e.From] = removeID(g.outEdges[e.From], id)
+	g.inEdges[e.To] = removeID(g.inEdges[e.To], id)
+	delete(g.edges, id)
+}
+
+func removeID(ids []EdgeID, target EdgeID) []EdgeID {
+	out := ids[:0]
+	for _, id := range ids {
+		if id != target {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// OutEdges returns the edges leaving node id.
+func (g *Graph) OutEdges(id NodeID) []*Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.edgesFor(g.outEdges[id])
+}
+
+// InEdges returns the edges arriving at node id.
+func (g *Graph) InEdges(id NodeID) []*Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.edgesFor(g.inEdges[id])
+}
+
+func (g *Graph) edgesFor(ids []EdgeID) []*Edge {
+	out := make([]*Edge, 0, len(ids))
+	for _, id := range ids {
+		if e, ok := g.edges[id]; ok {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// edgeByID returns the edge with the given ID, if it exists.
+func (g *Graph) edgeByID(id EdgeID) (*Edge, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	e, ok := g.edges[id]
+	return e, ok
+}
+
+// Degree returns the in-degree and out-degree of node id.
+func (g *Graph) Degree(id NodeID) (in, out int) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return len(g.inEdges[id]), len(g.outEdges[id])
+}
+
+// nodeProperty, nodeProperties, edgeProperties and setNodeProperty guard
+// direct access to a live Node/Edge's Properties map with g.mu, the same
+// mutex AddNode/RemoveNode/etc. use for the graph's own structure. Node
+// and Edge values are handed out by pointer (Node, Nodes, OutEdges, ...),
+// so without this their Properties maps would still be read and written
+// outside of any lock - exactly the data race the executor and VF2
+// matcher hit when a SET on one goroutine overlaps a MATCH on another.
+func (g *Graph) nodeProperty(n *Node, key string) any {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return n.Properties[key]
+}
+
+func (g *Graph) setNodeProperty(n *Node, key string, value any) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	n.Properties[key] = value
+}
+
+func (g *Graph) nodeProperties(n *Node) map[string]any {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return cloneProps(n.Properties)
+}
+
+func (g *Graph) edgeProperties(e *Edge) map[string]any {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return cloneProps(e.Properties)
+}
+
+func cloneProps(props map[string]any) map[string]any {
+	if props == nil {
+		return map[string]any{}
+	}
+	out := make(map[string]any, len(props))
+	for k, v := range props {
+		out[k] = v
+	}
+	return out
+}
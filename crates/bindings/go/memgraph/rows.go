@@ -0,0 +1,99 @@
+package memgraph
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// staticRows is a grafeo.Rows implementation over an already-materialized
+// set of result rows, as produced by Graph.Execute.
+type staticRows struct {
+	columns []string
+	data    [][]any
+	pos     int
+}
+
+func (r *staticRows) Columns() []string { return r.columns }
+
+func (r *staticRows) Next() bool {
+	r.pos++
+	return r.pos < len(r.data)
+}
+
+func (r *staticRows) Scan(dest ...any) error {
+	if r.pos < 0 || r.pos >= len(r.data) {
+		return fmt.Errorf("memgraph: Scan called without a valid row")
+	}
+	row := r.data[r.pos]
+	if len(dest) != len(row) {
+		return fmt.Errorf("memgraph: Scan expected %d destinations, got %d", len(row), len(dest))
+	}
+	for i, d := range dest {
+		if err := assign(d, row[i]); err != nil {
+			return fmt.Errorf("memgraph: Scan column %d (%s): %w", i, r.columns[i], err)
+		}
+	}
+	return nil
+}
+
+func (r *staticRows) Err() error   { return nil }
+func (r *staticRows) Close() error { return nil }
+
+// assign copies src into the pointer dest. Scalars are handled directly;
+// anything else (Node, Edge, []EdgeID, ...) falls back to reflection so
+// RETURN of a whole node or relationship works without a type-specific
+// case here.
+func assign(dest, src any) error {
+	switch d := dest.(type) {
+	case *any:
+		*d = src
+		return nil
+	case *string:
+		s, ok := src.(string)
+		if !ok {
+			return fmt.Errorf("source is not a string: %T", src)
+		}
+		*d = s
+		return nil
+	case *int64:
+		switch v := src.(type) {
+		case int64:
+			*d = v
+		case int:
+			*d = int64(v)
+		case float64:
+			*d = int64(v)
+		default:
+			return fmt.Errorf("source is not numeric: %T", src)
+		}
+		return nil
+	case *float64:
+		f, ok := asFloat(src)
+		if !ok {
+			return fmt.Errorf("source is not numeric: %T", src)
+		}
+		*d = f
+		return nil
+	case *bool:
+		v, ok := src.(bool)
+		if !ok {
+			return fmt.Errorf("source is not a bool: %T", src)
+		}
+		*d = v
+		return nil
+	}
+
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("Scan destination must be a non-nil pointer, got %T", dest)
+	}
+	if src == nil {
+		return nil
+	}
+	sv := reflect.ValueOf(src)
+	if !sv.Type().AssignableTo(dv.Elem().Type()) {
+		return fmt.Errorf("cannot assign %T into %T", src, dest)
+	}
+	dv.Elem().Set(sv)
+	return nil
+}
@@ -0,0 +1,427 @@
+package memgraph
+
+import (
+	"grafeo/cypher"
+)
+
+// maxVarLengthHops bounds BFS exploration for variable-length relationship
+// patterns (`*1..`) that specify no upper bound, so a single MATCH cannot
+// run away over a large or cyclic graph. An explicit upper bound above it
+// is capped to it as well.
+const maxVarLengthHops = 15
+
+// patEdge is a single relationship pattern within a chain pattern, indexed
+// by the positions of its two endpoint nodes in Pattern.Nodes.
+type patEdge struct {
+	rel  *cypher.RelPattern
+	a, b int // a connects to b in the textual order the pattern was written
+}
+
+// patternGraph is the compiled form of a cypher.Pattern used to drive VF2:
+// a short chain of node patterns connected by relationship patterns.
+type patternGraph struct {
+	nodes []*cypher.NodePattern
+	edges []patEdge
+}
+
+func buildPatternGraph(p *cypher.Pattern) *patternGraph {
+	pg := &patternGraph{nodes: p.Nodes}
+	for i, rel := range p.Rels {
+		pg.edges = append(pg.edges, patEdge{rel: rel, a: i, b: i + 1})
+	}
+	return pg
+}
+
+// edgesAt returns every patEdge incident to pattern node i.
+func (pg *patternGraph) edgesAt(i int) []patEdge {
+	var out []patEdge
+	for _, e := range pg.edges {
+		if e.a == i || e.b == i {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// patternDegree returns the in/out degree pattern node i requires,
+// accounting for direction. An Either edge counts toward neither, since
+// an edge in either direction can realize it.
+func (pg *patternGraph) patternDegree(i int) (in, out int) {
+	for _, e := range pg.edgesAt(i) {
+		switch e.rel.Direction {
+		case cypher.DirOutgoing:
+			if e.a == i {
+				out++
+			} else {
+				in++
+			}
+		case cypher.DirIncoming:
+			if e.a == i {
+				in++
+			} else {
+				out++
+			}
+		default: // DirEither: no specific in- or out-edge is required
+		}
+	}
+	return in, out
+}
+
+// propEvaluator evaluates a pattern's property map against a candidate
+// node/edge's stored properties, substituting $params.
+type propEvaluator func(pattern map[string]cypher.Expr, actual map[string]any) (bool, error)
+
+// matcher runs VF2-style backtracking search for one Pattern over one
+// Graph, reporting each complete mapping to onMatch.
+type matcher struct {
+	pg       *patternGraph
+	g        *Graph
+	evalProp propEvaluator
+
+	core1 []NodeID     // pattern idx -> data node (valid only if mapped[idx])
+	mapped []bool
+	core2    map[NodeID]int // data node -> pattern idx
+	pathEdges [][]EdgeID    // per pattern edge index, the data edge(s) realizing it for the current mapping
+}
+
+// Mapping is one complete match: for every pattern node/edge variable that
+// was named, the data node or edge(s) it was bound to.
+type Mapping struct {
+	Nodes map[string]NodeID
+	Edges map[string][]EdgeID
+}
+
+func newMatcher(pg *patternGraph, g *Graph, evalProp propEvaluator) *matcher {
+	return &matcher{
+		pg:        pg,
+		g:         g,
+		evalProp:  evalProp,
+		core1:     make([]NodeID, len(pg.nodes)),
+		mapped:    make([]bool, len(pg.nodes)),
+		core2:     map[NodeID]int{},
+		pathEdges: make([][]EdgeID, len(pg.edges)),
+	}
+}
+
+// Run performs the search, calling onMatch for every complete mapping.
+// onMatch returning an error aborts the search and propagates the error.
+func (m *matcher) Run(onMatch func(Mapping) error) error {
+	return m.search(0, onMatch)
+}
+
+func (m *matcher) numMapped() int {
+	n := 0
+	for _, ok := range m.mapped {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
+
+// nextPatternNode picks the next pattern node to extend the mapping with,
+// preferring a node adjacent to the already-mapped subgraph (so candidates
+// in the data graph are constrained) over an arbitrary unmapped node.
+func (m *matcher) nextPatternNode() int {
+	best := -1
+	bestScore := -1
+	for i := range m.pg.nodes {
+		if m.mapped[i] {
+			continue
+		}
+		adjacent := false
+		for _, e := range m.pg.edgesAt(i) {
+			other := e.a
+			if other == i {
+				other = e.b
+			}
+			if m.mapped[other] {
+				adjacent = true
+				break
+			}
+		}
+		score := 0
+		if adjacent {
+			score = 1
+		}
+		if score > bestScore {
+			bestScore = score
+			best = i
+		}
+	}
+	return best
+}
+
+func (m *matcher) search(depth int, onMatch func(Mapping) error) error {
+	if m.numMapped() == len(m.pg.nodes) {
+		return onMatch(m.snapshot())
+	}
+
+	p := m.nextPatternNode()
+	candidates, err := m.candidatesFor(p)
+	if err != nil {
+		return err
+	}
+
+	for _, dg := range candidates {
+		if _, used := m.core2[dg]; used {
+			continue
+		}
+		ok, matchedEdges, err := m.feasible(p, dg)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		m.core1[p] = dg
+		m.mapped[p] = true
+		m.core2[dg] = p
+		for edgeIdx, eids := range matchedEdges {
+			m.pathEdges[edgeIdx] = eids
+		}
+
+		if err := m.search(depth+1, onMatch); err != nil {
+			return err
+		}
+
+		delete(m.core2, dg)
+		m.mapped[p] = false
+		for edgeIdx := range matchedEdges {
+			m.pathEdges[edgeIdx] = nil
+		}
+	}
+	return nil
+}
+
+// candidatesFor returns the data nodes worth trying for pattern node p:
+// neighbors (via matching edge type/direction) of p's already-mapped
+// pattern neighbors, or every graph node if p has none yet.
+func (m *matcher) candidatesFor(p int) ([]NodeID, error) {
+	var mappedNeighbor *patEdge
+	for _, e := range m.pg.edgesAt(p) {
+		other := e.a
+		if other == p {
+			other = e.b
+		}
+		if m.mapped[other] {
+			mappedNeighbor = &e
+			break
+		}
+	}
+
+	if mappedNeighbor == nil {
+		all := m.g.Nodes()
+		out := make([]NodeID, len(all))
+		for i, n := range all {
+			out[i] = n.ID
+		}
+		return out, nil
+	}
+
+	e := *mappedNeighbor
+	other := e.a
+	if other == p {
+		other = e.b
+	}
+	anchor := m.core1[other]
+
+	reachable, err := m.reachableVia(anchor, e, other == e.a)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]NodeID, 0, len(reachable))
+	for nid := range reachable {
+		out = append(out, nid)
+	}
+	return out, nil
+}
+
+// reachableVia returns the data nodes reachable from anchor across pattern
+// edge e (anchorIsA indicates whether anchor realizes e.a), honoring
+// direction, type, and (for variable-length edges) the hop bounds. The
+// map's values are the edge path realizing each reachable node.
+func (m *matcher) reachableVia(anchor NodeID, e patEdge, anchorIsA bool) (map[NodeID][]EdgeID, error) {
+	// Direction as seen walking from the anchor toward the other endpoint.
+	forward := true // true: follow out-edges from anchor; false: follow in-edges
+	switch e.rel.Direction {
+	case cypher.DirOutgoing:
+		forward = anchorIsA
+	case cypher.DirIncoming:
+		forward = !anchorIsA
+	default: // Either: try both directions
+	}
+
+	min, max := e.rel.MinHops, e.rel.MaxHops
+	if !e.rel.VarLength {
+		min, max = 1, 1
+	}
+	if max < 0 || max > maxVarLengthHops {
+		max = maxVarLengthHops
+	}
+
+	type frontierEntry struct {
+		node NodeID
+		path []EdgeID
+	}
+	result := map[NodeID][]EdgeID{}
+	visited := map[NodeID]bool{anchor: true}
+	frontier := []frontierEntry{{node: anchor, path: nil}}
+
+	for hop := 1; hop <= max; hop++ {
+		var next []frontierEntry
+		for _, fe := range frontier {
+			steps := m.stepsFrom(fe.node, e.rel, forward, e.rel.Direction == cypher.DirEither)
+			for _, step := range steps {
+				path := append(append([]EdgeID(nil), fe.path...), step.edge)
+				if hop >= min {
+					if _, ok := result[step.to]; !ok {
+						result[step.to] = path
+					}
+				}
+				if visited[step.to] && hop > 1 {
+					// A revisited node can still be this hop's path
+					// target (recorded above), but its frontier is not
+					// re-expanded, which avoids infinite loops.
+					continue
+				}
+				next = append(next, frontierEntry{node: step.to, path: path})
+				visited[step.to] = true
+			}
+		}
+		frontier = next
+		if len(frontier) == 0 {
+			break
+		}
+	}
+	return result, nil
+}
+
+type step struct {
+	to   NodeID
+	edge EdgeID
+}
+
+func (m *matcher) stepsFrom(from NodeID, rel *cypher.RelPattern, forward, either bool) []step {
+	var out []step
+	typeOK := func(t string) bool {
+		if len(rel.Types) == 0 {
+			return true
+		}
+		for _, want := range rel.Types {
+			if want == t {
+				return true
+			}
+		}
+		return false
+	}
+	if forward || either {
+		for _, edge := range m.g.OutEdges(from) {
+			if typeOK(edge.Type) {
+				out = append(out, step{to: edge.To, edge: edge.ID})
+			}
+		}
+	}
+	if !forward || either {
+		for _, edge := range m.g.InEdges(from) {
+			if typeOK(edge.Type) {
+				out = append(out, step{to: edge.From, edge: edge.ID})
+			}
+		}
+	}
+	return out
+}
+
+// feasible applies the VF2 pruning rules for tentatively mapping pattern
+// node p to data node dg: label/property compatibility, the degree filter,
+// and consistency against already-mapped neighbors. It does no look-ahead:
+// candidates are not pruned by comparing unmapped-neighbor counts. It
+// returns the data edges that would realize each now-satisfiable pattern
+// edge.
+func (m *matcher) feasible(p int, dg NodeID) (bool, map[int][]EdgeID, error) {
+	node, ok := m.g.Node(dg)
+	if !ok {
+		return false, nil, nil
+	}
+	pn := m.pg.nodes[p]
+	for _, want := range pn.Labels {
+		if !node.HasLabel(want) {
+			return false, nil, nil
+		}
+	}
+	if pn.Properties != nil {
+		ok, err := m.evalProp(pn.Properties, m.g.nodeProperties(node))
+		if err != nil {
+			return false, nil, err
+		}
+		if !ok {
+			return false, nil, nil
+		}
+	}
+
+	wantIn, wantOut := m.pg.patternDegree(p)
+	gotIn, gotOut := m.g.Degree(dg)
+	if gotIn < wantIn || gotOut < wantOut {
+		return false, nil, nil
+	}
+
+	matched := map[int][]EdgeID{}
+	for ei, e := range m.pg.edges {
+		if e.a != p && e.b != p {
+			continue
+		}
+		other := e.a
+		anchorIsA := false
+		if other == p {
+			other = e.b
+			anchorIsA = true
+		}
+		if !m.mapped[other] {
+			continue
+		}
+		reachable, err := m.reachableVia(dg, e, anchorIsA)
+		if err != nil {
+			return false, nil, err
+		}
+		target := m.core1[other]
+		path, ok := reachable[target]
+		if !ok {
+			return false, nil, nil
+		}
+		if e.rel.Properties != nil {
+			// property constraints on relationships only make sense for a
+			// single concrete edge; for variable-length paths we only
+			// constrain the final hop.
+			lastEdge, _ := m.g.edgeByID(path[len(path)-1])
+			if lastEdge == nil {
+				return false, nil, nil
+			}
+			ok, err := m.evalProp(e.rel.Properties, m.g.edgeProperties(lastEdge))
+			if err != nil {
+				return false, nil, err
+			}
+			if !ok {
+				return false, nil, nil
+			}
+		}
+		matched[ei] = path
+	}
+
+	return true, matched, nil
+}
+
+func (m *matcher) snapshot() Mapping {
+	mapping := Mapping{Nodes: map[string]NodeID{}, Edges: map[string][]EdgeID{}}
+	for i, n := range m.pg.nodes {
+		if n.Variable != "" {
+			mapping.Nodes[n.Variable] = m.core1[i]
+		}
+	}
+	for i, e := range m.pg.edges {
+		if e.rel.Variable != "" {
+			mapping.Edges[e.rel.Variable] = m.pathEdges[i]
+		}
+	}
+	return mapping
+}
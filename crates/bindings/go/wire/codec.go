@@ -0,0 +1,481 @@
+package wire
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Encoder writes PackStream-like typed values to an underlying writer.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder returns an Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// EncodeValue writes a single typed value: nil, bool, int or int64 (both
+// encoded as int64), float32/float64, string, []byte, []any, map[string]any,
+// Node, Relationship, or Path. Other integer types are rejected with an
+// error.
+func (e *Encoder) EncodeValue(v any) error {
+	switch x := v.(type) {
+	case nil:
+		return e.writeByte(tagNull)
+	case bool:
+		if x {
+			return e.writeByte(tagTrue)
+		}
+		return e.writeByte(tagFalse)
+	case int:
+		return e.encodeInt(int64(x))
+	case int64:
+		return e.encodeInt(x)
+	case float32:
+		return e.encodeFloat(float64(x))
+	case float64:
+		return e.encodeFloat(x)
+	case string:
+		return e.encodeString(x)
+	case []byte:
+		return e.encodeBytes(x)
+	case []any:
+		return e.encodeList(x)
+	case map[string]any:
+		return e.encodeMap(x)
+	case Node:
+		return e.encodeNode(x)
+	case Relationship:
+		return e.encodeRelationship(x)
+	case Path:
+		return e.encodePath(x)
+	default:
+		return fmt.Errorf("wire: value of type %T is not encodable", v)
+	}
+}
+
+func (e *Encoder) writeByte(b byte) error {
+	_, err := e.w.Write([]byte{b})
+	return err
+}
+
+func (e *Encoder) encodeInt(v int64) error {
+	if err := e.writeByte(tagInt); err != nil {
+		return err
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(v))
+	_, err := e.w.Write(buf[:])
+	return err
+}
+
+func (e *Encoder) encodeFloat(v float64) error {
+	if err := e.writeByte(tagFloat); err != nil {
+		return err
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], math.Float64bits(v))
+	_, err := e.w.Write(buf[:])
+	return err
+}
+
+func (e *Encoder) encodeString(s string) error {
+	if err := e.writeByte(tagString); err != nil {
+		return err
+	}
+	return e.writeLengthPrefixed([]byte(s))
+}
+
+func (e *Encoder) encodeBytes(b []byte) error {
+	if err := e.writeByte(tagBytes); err != nil {
+		return err
+	}
+	return e.writeLengthPrefixed(b)
+}
+
+func (e *Encoder) writeLengthPrefixed(b []byte) error {
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(b)))
+	if _, err := e.w.Write(lenBuf[:]); err != nil {
+		return err
+	}
+	_, err := e.w.Write(b)
+	return err
+}
+
+func (e *Encoder) encodeList(items []any) error {
+	if err := e.writeByte(tagList); err != nil {
+		return err
+	}
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(items)))
+	if _, err := e.w.Write(lenBuf[:]); err != nil {
+		return err
+	}
+	for _, it := range items {
+		if err := e.EncodeValue(it); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) encodeMap(m map[string]any) error {
+	if err := e.writeByte(tagMap); err != nil {
+		return err
+	}
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(m)))
+	if _, err := e.w.Write(lenBuf[:]); err != nil {
+		return err
+	}
+	for k, v := range m {
+		if err := e.encodeString(k); err != nil {
+			return err
+		}
+		if err := e.EncodeValue(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) encodeNode(n Node) error {
+	if err := e.writeByte(tagNode); err != nil {
+		return err
+	}
+	if err := e.encodeInt(n.ID); err != nil {
+		return err
+	}
+	labels := make([]any, len(n.Labels))
+	for i, l := range n.Labels {
+		labels[i] = l
+	}
+	if err := e.encodeList(labels); err != nil {
+		return err
+	}
+	return e.encodeMap(n.Properties)
+}
+
+func (e *Encoder) encodeRelationship(r Relationship) error {
+	if err := e.writeByte(tagRelationship); err != nil {
+		return err
+	}
+	if err := e.encodeInt(r.ID); err != nil {
+		return err
+	}
+	if err := e.encodeString(r.Type); err != nil {
+		return err
+	}
+	if err := e.encodeInt(r.StartNodeID); err != nil {
+		return err
+	}
+	if err := e.encodeInt(r.EndNodeID); err != nil {
+		return err
+	}
+	return e.encodeMap(r.Properties)
+}
+
+func (e *Encoder) encodePath(p Path) error {
+	if err := e.writeByte(tagPath); err != nil {
+		return err
+	}
+	nodes := make([]any, len(p.Nodes))
+	for i, n := range p.Nodes {
+		nodes[i] = n
+	}
+	if err := e.encodeList(nodes); err != nil {
+		return err
+	}
+	rels := make([]any, len(p.Relationships))
+	for i, r := range p.Relationships {
+		rels[i] = r
+	}
+	if err := e.encodeList(rels); err != nil {
+		return err
+	}
+	seq := make([]any, len(p.Sequence))
+	for i, s := range p.Sequence {
+		seq[i] = s
+	}
+	return e.encodeList(seq)
+}
+
+// Decoder reads PackStream-like typed values from an underlying reader.
+type Decoder struct {
+	r io.Reader
+}
+
+// NewDecoder returns a Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// DecodeValue reads and returns a single typed value, the inverse of
+// Encoder.EncodeValue.
+func (d *Decoder) DecodeValue() (any, error) {
+	tag, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case tagNull:
+		return nil, nil
+	case tagTrue:
+		return true, nil
+	case tagFalse:
+		return false, nil
+	case tagInt:
+		return d.readInt()
+	case tagFloat:
+		return d.readFloat()
+	case tagString:
+		b, err := d.readLengthPrefixed()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case tagBytes:
+		return d.readLengthPrefixed()
+	case tagList:
+		return d.readList()
+	case tagMap:
+		return d.readMap()
+	case tagNode:
+		return d.readNode()
+	case tagRelationship:
+		return d.readRelationship()
+	case tagPath:
+		return d.readPath()
+	default:
+		return nil, fmt.Errorf("wire: unknown value tag %d", tag)
+	}
+}
+
+func (d *Decoder) readByte() (byte, error) {
+	var buf [1]byte
+	if _, err := io.ReadFull(d.r, buf[:]); err != nil {
+		return 0, err
+	}
+	return buf[0], nil
+}
+
+func (d *Decoder) readInt() (int64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(d.r, buf[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(buf[:])), nil
+}
+
+func (d *Decoder) readFloat() (float64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(d.r, buf[:]); err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(buf[:])), nil
+}
+
+// maxElemCount bounds the element/byte counts Decoder will honor out of a
+// length prefix, so a peer can't force a huge make([]byte, n) /
+// make([]any, n) / make(map[string]any, n) allocation with a single
+// forged uint32 before any of its actual bytes have even arrived.
+const maxElemCount = 1 << 24 // 16Mi bytes/elements
+
+func (d *Decoder) readLengthPrefixed() ([]byte, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(d.r, lenBuf[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(lenBuf[:])
+	if n > maxElemCount {
+		return nil, fmt.Errorf("wire: length-prefixed value of %d bytes exceeds the %d byte limit", n, maxElemCount)
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(d.r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func (d *Decoder) readList() ([]any, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(d.r, lenBuf[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(lenBuf[:])
+	if n > maxElemCount {
+		return nil, fmt.Errorf("wire: list of %d elements exceeds the %d element limit", n, maxElemCount)
+	}
+	out := make([]any, n)
+	for i := range out {
+		v, err := d.DecodeValue()
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func (d *Decoder) readMap() (map[string]any, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(d.r, lenBuf[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(lenBuf[:])
+	if n > maxElemCount {
+		return nil, fmt.Errorf("wire: map of %d entries exceeds the %d entry limit", n, maxElemCount)
+	}
+	out := make(map[string]any, n)
+	for i := uint32(0); i < n; i++ {
+		keyVal, err := d.DecodeValue()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := keyVal.(string)
+		if !ok {
+			return nil, fmt.Errorf("wire: map key is not a string: %T", keyVal)
+		}
+		val, err := d.DecodeValue()
+		if err != nil {
+			return nil, err
+		}
+		out[key] = val
+	}
+	return out, nil
+}
+
+func (d *Decoder) readNode() (Node, error) {
+	id, err := d.readTaggedInt()
+	if err != nil {
+		return Node{}, err
+	}
+	labelsAny, err := d.readTaggedList()
+	if err != nil {
+		return Node{}, err
+	}
+	labels := make([]string, len(labelsAny))
+	for i, l := range labelsAny {
+		s, _ := l.(string)
+		labels[i] = s
+	}
+	props, err := d.readTaggedMap()
+	if err != nil {
+		return Node{}, err
+	}
+	return Node{ID: id, Labels: labels, Properties: props}, nil
+}
+
+func (d *Decoder) readRelationship() (Relationship, error) {
+	id, err := d.readTaggedInt()
+	if err != nil {
+		return Relationship{}, err
+	}
+	typ, err := d.readTaggedString()
+	if err != nil {
+		return Relationship{}, err
+	}
+	start, err := d.readTaggedInt()
+	if err != nil {
+		return Relationship{}, err
+	}
+	end, err := d.readTaggedInt()
+	if err != nil {
+		return Relationship{}, err
+	}
+	props, err := d.readTaggedMap()
+	if err != nil {
+		return Relationship{}, err
+	}
+	return Relationship{ID: id, Type: typ, StartNodeID: start, EndNodeID: end, Properties: props}, nil
+}
+
+func (d *Decoder) readPath() (Path, error) {
+	nodesAny, err := d.readTaggedList()
+	if err != nil {
+		return Path{}, err
+	}
+	relsAny, err := d.readTaggedList()
+	if err != nil {
+		return Path{}, err
+	}
+	seqAny, err := d.readTaggedList()
+	if err != nil {
+		return Path{}, err
+	}
+	p := Path{
+		Nodes:         make([]Node, len(nodesAny)),
+		Relationships: make([]Relationship, len(relsAny)),
+		Sequence:      make([]int64, len(seqAny)),
+	}
+	for i, n := range nodesAny {
+		p.Nodes[i], _ = n.(Node)
+	}
+	for i, r := range relsAny {
+		p.Relationships[i], _ = r.(Relationship)
+	}
+	for i, s := range seqAny {
+		p.Sequence[i], _ = s.(int64)
+	}
+	return p, nil
+}
+
+// readTaggedList/readTaggedMap decode a nested value that is expected to
+// carry its own tagList/tagMap prefix (nodes, relationships and paths
+// nest fully tagged values, not bare length-prefixed ones).
+func (d *Decoder) readTaggedList() ([]any, error) {
+	v, err := d.DecodeValue()
+	if err != nil {
+		return nil, err
+	}
+	list, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("wire: expected list, got %T", v)
+	}
+	return list, nil
+}
+
+func (d *Decoder) readTaggedMap() (map[string]any, error) {
+	v, err := d.DecodeValue()
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("wire: expected map, got %T", v)
+	}
+	return m, nil
+}
+
+// readTaggedInt/readTaggedString decode a nested value expected to carry
+// its own tagInt/tagString prefix, the same way encodeInt/encodeString
+// write one - used for the Node/Relationship ID, Type and endpoint fields,
+// which encodeNode/encodeRelationship write through encodeInt/encodeString
+// rather than bare.
+func (d *Decoder) readTaggedInt() (int64, error) {
+	v, err := d.DecodeValue()
+	if err != nil {
+		return 0, err
+	}
+	n, ok := v.(int64)
+	if !ok {
+		return 0, fmt.Errorf("wire: expected int, got %T", v)
+	}
+	return n, nil
+}
+
+func (d *Decoder) readTaggedString() (string, error) {
+	v, err := d.DecodeValue()
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("wire: expected string, got %T", v)
+	}
+	return s, nil
+}
@@ -0,0 +1,98 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	in := map[string]any{
+		"s":    "hello",
+		"i":    int64(42),
+		"list": []any{int64(1), int64(2), int64(3)},
+	}
+	if err := NewEncoder(&buf).EncodeValue(in); err != nil {
+		t.Fatalf("EncodeValue: %v", err)
+	}
+	out, err := NewDecoder(&buf).DecodeValue()
+	if err != nil {
+		t.Fatalf("DecodeValue: %v", err)
+	}
+	m, ok := out.(map[string]any)
+	if !ok {
+		t.Fatalf("got %T, want map[string]any", out)
+	}
+	if m["s"] != "hello" || m["i"] != int64(42) {
+		t.Fatalf("round-tripped map mismatch: %#v", m)
+	}
+}
+
+// TestCodecRoundTripGraphValues is a regression test for a tag/bare
+// mismatch in readNode/readRelationship: encodeNode and encodeRelationship
+// write their ID/Type fields through encodeInt/encodeString, which prefix
+// a tag byte, but the decoder used to read them back with the tag-unaware
+// readInt/readLengthPrefixed, desyncing every field after it.
+func TestCodecRoundTripGraphValues(t *testing.T) {
+	node := Node{ID: 7, Labels: []string{"Person"}, Properties: map[string]any{"name": "Ann"}}
+	rel := Relationship{ID: 9, Type: "KNOWS", StartNodeID: 7, EndNodeID: 8, Properties: map[string]any{"since": int64(2020)}}
+	path := Path{
+		Nodes:         []Node{node},
+		Relationships: []Relationship{rel},
+		Sequence:      []int64{1, 1},
+	}
+
+	for name, in := range map[string]any{"node": node, "relationship": rel, "path": path} {
+		var buf bytes.Buffer
+		if err := NewEncoder(&buf).EncodeValue(in); err != nil {
+			t.Fatalf("%s: EncodeValue: %v", name, err)
+		}
+		out, err := NewDecoder(&buf).DecodeValue()
+		if err != nil {
+			t.Fatalf("%s: DecodeValue: %v", name, err)
+		}
+		switch name {
+		case "node":
+			got, ok := out.(Node)
+			if !ok || got.ID != node.ID || got.Labels[0] != "Person" || got.Properties["name"] != "Ann" {
+				t.Fatalf("node round-trip mismatch: %#v", out)
+			}
+		case "relationship":
+			got, ok := out.(Relationship)
+			if !ok || got.ID != rel.ID || got.Type != "KNOWS" || got.StartNodeID != 7 || got.EndNodeID != 8 {
+				t.Fatalf("relationship round-trip mismatch: %#v", out)
+			}
+		case "path":
+			got, ok := out.(Path)
+			if !ok || len(got.Nodes) != 1 || len(got.Relationships) != 1 || len(got.Sequence) != 2 {
+				t.Fatalf("path round-trip mismatch: %#v", out)
+			}
+		}
+	}
+}
+
+func TestDecodeRejectsOversizedLength(t *testing.T) {
+	// tagString followed by a length prefix far beyond maxElemCount: a
+	// malicious peer shouldn't be able to force make([]byte, n) this way.
+	var buf bytes.Buffer
+	buf.WriteByte(tagString)
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], 0xFFFFFFF0)
+	buf.Write(lenBuf[:])
+
+	if _, err := NewDecoder(&buf).DecodeValue(); err == nil {
+		t.Fatal("DecodeValue: got nil error, want a size-limit error")
+	}
+}
+
+func TestDecodeMessageRejectsOversizedFrame(t *testing.T) {
+	var buf bytes.Buffer
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], 0xFFFFFFF0)
+	buf.Write(lenBuf[:])
+
+	if _, err := DecodeMessage(&buf); err == nil {
+		t.Fatal("DecodeMessage: got nil error, want a frame-size-limit error")
+	}
+}
@@ -0,0 +1,187 @@
+package wire
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+// Conn is a client connection to a wire-protocol server. Requests may be
+// pipelined: Send can be called any number of times before Receive is
+// called, and responses are read back in the same order the requests
+// were sent, exactly like Bolt's pipelining. Conn is safe for concurrent
+// Send calls and concurrent Receive calls, but pairs of (Send, Receive)
+// from different goroutines are only ordered with respect to each other
+// if the caller serializes them.
+type Conn struct {
+	nc      net.Conn
+	Version uint32
+
+	writeMu sync.Mutex
+	readMu  sync.Mutex
+}
+
+// Dial opens a TCP connection to addr and performs the handshake,
+// proposing CurrentVersion (and any older versions the caller also
+// speaks).
+func Dial(addr string, proposedVersions ...uint32) (*Conn, error) {
+	if len(proposedVersions) == 0 {
+		proposedVersions = []uint32{CurrentVersion}
+	}
+	nc, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("wire: dial %s: %w", addr, err)
+	}
+	version, err := ClientHandshake(nc, proposedVersions)
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+	return &Conn{nc: nc, Version: version}, nil
+}
+
+// Send writes a single message frame. Multiple Sends may be pipelined
+// ahead of their Receives.
+func (c *Conn) Send(m Message) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return EncodeMessage(c.nc, m)
+}
+
+// Receive reads the next response frame, in the order responses were
+// produced by the server (FIFO with respect to Sends).
+func (c *Conn) Receive() (Message, error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+	return DecodeMessage(c.nc)
+}
+
+// Pipeline sends every message in msgs back-to-back, then reads exactly
+// len(msgs) responses, returning them in the same order. This is the
+// common case of pipelining: fire off a batch of requests without
+// waiting on each one's response individually.
+func (c *Conn) Pipeline(msgs []Message) ([]Message, error) {
+	for _, m := range msgs {
+		if err := c.Send(m); err != nil {
+			return nil, err
+		}
+	}
+	out := make([]Message, len(msgs))
+	for i := range msgs {
+		resp, err := c.Receive()
+		if err != nil {
+			return nil, err
+		}
+		out[i] = resp
+	}
+	return out, nil
+}
+
+// Run sends RUN for query/params, returning the RUN response's fields
+// (typically {"fields": [...]}). It does not send HELLO; callers send
+// that once themselves after Dial.
+func (c *Conn) Run(query string, params map[string]any) (map[string]any, error) {
+	if err := c.Send(Run(query, params)); err != nil {
+		return nil, err
+	}
+	resp, err := c.Receive()
+	if err != nil {
+		return nil, err
+	}
+	if resp.Kind == KindFailure {
+		return nil, &Error{Message: fmt.Sprint(resp.Fields["message"])}
+	}
+	if resp.Kind != KindSuccess {
+		return nil, fmt.Errorf("wire: unexpected response kind %d to RUN", resp.Kind)
+	}
+	return resp.Fields, nil
+}
+
+// Pull requests up to n records (n < 0 for "all remaining"). It returns
+// the records received and whether more records remain to be pulled.
+func (c *Conn) Pull(n int64) (records [][]any, hasMore bool, err error) {
+	if err := c.Send(Pull(n)); err != nil {
+		return nil, false, err
+	}
+	for {
+		resp, err := c.Receive()
+		if err != nil {
+			return nil, false, err
+		}
+		switch resp.Kind {
+		case KindRecord:
+			values, _ := resp.Fields["values"].([]any)
+			records = append(records, values)
+		case KindSuccess:
+			hasMore, _ = resp.Fields["has_more"].(bool)
+			return records, hasMore, nil
+		case KindFailure:
+			return nil, false, &Error{Message: fmt.Sprint(resp.Fields["message"])}
+		default:
+			return nil, false, fmt.Errorf("wire: unexpected response kind %d during PULL", resp.Kind)
+		}
+	}
+}
+
+// Discard abandons the remainder of the current result.
+func (c *Conn) Discard() error {
+	return c.sendAck(Discard())
+}
+
+// Begin opens an explicit transaction.
+func (c *Conn) Begin(readOnly bool, bookmarks []string) error {
+	return c.sendAck(Begin(readOnly, bookmarks))
+}
+
+// Commit commits the current explicit transaction.
+func (c *Conn) Commit() error { return c.sendAck(Commit()) }
+
+// Rollback rolls back the current explicit transaction.
+func (c *Conn) Rollback() error { return c.sendAck(Rollback()) }
+
+// Reset clears any failure state and pending result, returning the
+// connection to a clean state ready for reuse (e.g. by a connection
+// pool).
+func (c *Conn) Reset() error { return c.sendAck(Reset()) }
+
+// Goodbye tells the server this connection is closing and releases the
+// underlying socket.
+func (c *Conn) Goodbye() error {
+	if err := c.Send(Goodbye()); err != nil {
+		c.nc.Close()
+		return err
+	}
+	return c.nc.Close()
+}
+
+// Close closes the underlying connection without sending GOODBYE.
+func (c *Conn) Close() error {
+	return c.nc.Close()
+}
+
+func (c *Conn) sendAck(m Message) error {
+	if err := c.Send(m); err != nil {
+		return err
+	}
+	resp, err := c.Receive()
+	if err != nil {
+		return err
+	}
+	if resp.Kind == KindFailure {
+		return &Error{Message: fmt.Sprint(resp.Fields["message"])}
+	}
+	if resp.Kind != KindSuccess {
+		return fmt.Errorf("wire: unexpected response kind %d", resp.Kind)
+	}
+	return nil
+}
+
+// Error is returned when the server responds with a FAILURE message.
+type Error struct {
+	Message string
+}
+
+func (e *Error) Error() string { return "wire: " + e.Message }
+
+var _ io.Closer = (*Conn)(nil)
@@ -0,0 +1,75 @@
+package wire
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// magic precedes every handshake, the same way Bolt's 0x6060B017 lets a
+// server immediately reject a connection that isn't speaking this protocol.
+const magic uint32 = 0x47524146 // "GRAF"
+
+// CurrentVersion is the protocol version this package implements.
+const CurrentVersion uint32 = 1
+
+// ClientHandshake writes the magic preamble followed by up to four
+// candidate versions (highest preference first) and reads back the
+// version the server chose, or an error if none matched.
+func ClientHandshake(rw io.ReadWriter, proposed []uint32) (uint32, error) {
+	if len(proposed) == 0 || len(proposed) > 4 {
+		return 0, fmt.Errorf("wire: ClientHandshake needs 1-4 proposed versions, got %d", len(proposed))
+	}
+	var buf [20]byte
+	binary.BigEndian.PutUint32(buf[0:4], magic)
+	for i := 0; i < 4; i++ {
+		v := uint32(0)
+		if i < len(proposed) {
+			v = proposed[i]
+		}
+		binary.BigEndian.PutUint32(buf[4+i*4:8+i*4], v)
+	}
+	if _, err := rw.Write(buf[:]); err != nil {
+		return 0, err
+	}
+
+	var respBuf [4]byte
+	if _, err := io.ReadFull(rw, respBuf[:]); err != nil {
+		return 0, err
+	}
+	version := binary.BigEndian.Uint32(respBuf[:])
+	if version == 0 {
+		return 0, fmt.Errorf("wire: server supports none of the proposed versions %v", proposed)
+	}
+	return version, nil
+}
+
+// ServerHandshake reads the client's magic preamble and candidate
+// versions, writes back the highest one this package supports, and
+// returns it (0 and an error if none match).
+func ServerHandshake(rw io.ReadWriter) (uint32, error) {
+	var buf [20]byte
+	if _, err := io.ReadFull(rw, buf[:]); err != nil {
+		return 0, err
+	}
+	if binary.BigEndian.Uint32(buf[0:4]) != magic {
+		return 0, fmt.Errorf("wire: bad handshake magic")
+	}
+	chosen := uint32(0)
+	for i := 0; i < 4; i++ {
+		v := binary.BigEndian.Uint32(buf[4+i*4 : 8+i*4])
+		if v == CurrentVersion {
+			chosen = v
+			break
+		}
+	}
+	var respBuf [4]byte
+	binary.BigEndian.PutUint32(respBuf[:], chosen)
+	if _, err := rw.Write(respBuf[:]); err != nil {
+		return 0, err
+	}
+	if chosen == 0 {
+		return 0, fmt.Errorf("wire: no proposed version matches %d", CurrentVersion)
+	}
+	return chosen, nil
+}
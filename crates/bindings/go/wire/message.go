@@ -0,0 +1,177 @@
+package wire
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Kind identifies a protocol message.
+type Kind byte
+
+const (
+	KindHello Kind = iota + 1
+	KindRun
+	KindPull
+	KindDiscard
+	KindBegin
+	KindCommit
+	KindRollback
+	KindReset
+	KindGoodbye
+
+	// Server-originated responses.
+	KindSuccess
+	KindRecord
+	KindFailure
+)
+
+// Message is one protocol frame: a Kind plus its named fields, e.g. a RUN
+// message carries {"query": ..., "params": ...} and a RECORD message
+// carries {"values": [...]}.
+type Message struct {
+	Kind   Kind
+	Fields map[string]any
+}
+
+// Hello builds a client HELLO message, sent once right after the
+// handshake to identify the driver/agent.
+func Hello(agent string) Message {
+	return Message{Kind: KindHello, Fields: map[string]any{"agent": agent}}
+}
+
+// Run builds a client RUN message: submit a query with its parameters.
+func Run(query string, params map[string]any) Message {
+	if params == nil {
+		params = map[string]any{}
+	}
+	return Message{Kind: KindRun, Fields: map[string]any{"query": query, "params": params}}
+}
+
+// Pull builds a client PULL message requesting up to n more records
+// (n < 0 means "all remaining").
+func Pull(n int64) Message {
+	return Message{Kind: KindPull, Fields: map[string]any{"n": n}}
+}
+
+// Discard builds a client DISCARD message, abandoning the rest of the
+// current result without streaming it back.
+func Discard() Message {
+	return Message{Kind: KindDiscard}
+}
+
+// Begin builds a client BEGIN message opening an explicit transaction.
+func Begin(readOnly bool, bookmarks []string) Message {
+	bm := make([]any, len(bookmarks))
+	for i, b := range bookmarks {
+		bm[i] = b
+	}
+	return Message{Kind: KindBegin, Fields: map[string]any{"readOnly": readOnly, "bookmarks": bm}}
+}
+
+// Commit, Rollback, Reset and Goodbye carry no fields.
+func Commit() Message   { return Message{Kind: KindCommit} }
+func Rollback() Message { return Message{Kind: KindRollback} }
+func Reset() Message    { return Message{Kind: KindReset} }
+func Goodbye() Message  { return Message{Kind: KindGoodbye} }
+
+// Success builds a server SUCCESS response.
+func Success(fields map[string]any) Message {
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	return Message{Kind: KindSuccess, Fields: fields}
+}
+
+// Record builds a server RECORD response carrying one result row.
+func Record(values []any) Message {
+	vals := make([]any, len(values))
+	copy(vals, values)
+	return Message{Kind: KindRecord, Fields: map[string]any{"values": vals}}
+}
+
+// Failure builds a server FAILURE response.
+func Failure(message string) Message {
+	return Message{Kind: KindFailure, Fields: map[string]any{"message": message}}
+}
+
+// EncodeMessage writes m as a length-prefixed frame: a 4-byte big-endian
+// length covering the kind byte and the encoded fields map, followed by
+// that payload.
+func EncodeMessage(w io.Writer, m Message) error {
+	var buf []byte
+	bw := &byteBuffer{buf: buf}
+	bw.buf = append(bw.buf, byte(m.Kind))
+	enc := NewEncoder(bw)
+	fields := m.Fields
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	if err := enc.encodeMap(fields); err != nil {
+		return err
+	}
+
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(bw.buf)))
+	if _, err := w.Write(lenBuf[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(bw.buf)
+	return err
+}
+
+// maxFrameSize bounds the length prefix DecodeMessage will honor, so a
+// peer (the wire.Server's net.Conn, or any response a wire.Conn client
+// trusts) can't force a multi-GB allocation with a single forged length.
+const maxFrameSize = 64 << 20 // 64MiB
+
+// DecodeMessage reads back a single frame written by EncodeMessage.
+func DecodeMessage(r io.Reader) (Message, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return Message{}, err
+	}
+	n := binary.BigEndian.Uint32(lenBuf[:])
+	if n > maxFrameSize {
+		return Message{}, fmt.Errorf("wire: message frame of %d bytes exceeds the %d byte limit", n, maxFrameSize)
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return Message{}, err
+	}
+	if len(payload) < 1 {
+		return Message{}, fmt.Errorf("wire: empty message frame")
+	}
+	kind := Kind(payload[0])
+	dec := NewDecoder(&byteBuffer{buf: payload[1:]})
+	// encodeMap (used by EncodeMessage above) writes its own tagMap byte,
+	// so decoding must go through the tag-aware path rather than readMap,
+	// which expects the length prefix with no tag ahead of it.
+	fields, err := dec.readTaggedMap()
+	if err != nil {
+		return Message{}, fmt.Errorf("wire: decode message fields: %w", err)
+	}
+	return Message{Kind: kind, Fields: fields}, nil
+}
+
+// byteBuffer is a minimal in-memory io.ReadWriter over a byte slice, used
+// to stage an encoded message so its total length can be framed before
+// it is written to the real connection.
+type byteBuffer struct {
+	buf []byte
+	pos int
+}
+
+func (b *byteBuffer) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+func (b *byteBuffer) Read(p []byte) (int, error) {
+	if b.pos >= len(b.buf) {
+		return 0, io.EOF
+	}
+	n := copy(p, b.buf[b.pos:])
+	b.pos += n
+	return n, nil
+}
@@ -0,0 +1,47 @@
+package wire
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestMessageRoundTrip is a regression test for a framing mismatch:
+// EncodeMessage writes its fields via encodeMap, which prefixes a tagMap
+// byte, but DecodeMessage used to read the fields back with the
+// tag-unaware readMap, misreading that tag byte as part of the length
+// prefix and failing on any non-empty Fields.
+func TestMessageRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	want := Run("MATCH (n) RETURN n", map[string]any{"limit": int64(10)})
+	if err := EncodeMessage(&buf, want); err != nil {
+		t.Fatalf("EncodeMessage: %v", err)
+	}
+	got, err := DecodeMessage(&buf)
+	if err != nil {
+		t.Fatalf("DecodeMessage: %v", err)
+	}
+	if got.Kind != want.Kind {
+		t.Fatalf("Kind: got %d, want %d", got.Kind, want.Kind)
+	}
+	if got.Fields["query"] != "MATCH (n) RETURN n" {
+		t.Fatalf("Fields[query]: got %#v", got.Fields["query"])
+	}
+	params, ok := got.Fields["params"].(map[string]any)
+	if !ok || params["limit"] != int64(10) {
+		t.Fatalf("Fields[params]: got %#v", got.Fields["params"])
+	}
+}
+
+func TestMessageRoundTripEmptyFields(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeMessage(&buf, Goodbye()); err != nil {
+		t.Fatalf("EncodeMessage: %v", err)
+	}
+	got, err := DecodeMessage(&buf)
+	if err != nil {
+		t.Fatalf("DecodeMessage: %v", err)
+	}
+	if got.Kind != KindGoodbye {
+		t.Fatalf("Kind: got %d, want KindGoodbye", got.Kind)
+	}
+}
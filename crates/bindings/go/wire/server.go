@@ -0,0 +1,148 @@
+package wire
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// Cursor streams one query's result rows without requiring the Handler to
+// buffer the whole result set; DISCARD, RESET, a new RUN, or the
+// connection ending all stop it early by closing it without further Next
+// calls. Server.Close does not stop cursors on connections already open.
+type Cursor interface {
+	// Columns returns the result's column names.
+	Columns() []string
+	// Next returns the next row, or ok=false once the result is exhausted.
+	Next() (row []any, ok bool, err error)
+	// Close releases resources held by the cursor.
+	Close() error
+}
+
+// Handler executes a query and returns a Cursor over its results. Handler
+// takes a parsed-from-string query rather than a grafeo.Executor's
+// *cypher.Query so this package never has to import grafeo (which already
+// imports wire); grafeo.ServeWire adapts any grafeo.Executor - including
+// the in-memory engine, grafeo/memgraph - into a Handler by parsing the
+// query itself before calling Execute.
+type Handler interface {
+	Execute(ctx context.Context, query string, params map[string]any) (Cursor, error)
+}
+
+// Server accepts wire-protocol connections on a net.Listener and serves
+// them against a Handler. It exists primarily so the codec implemented in
+// this package can be reused by the in-memory engine in tests, without
+// requiring a real network round trip to a separate process.
+type Server struct {
+	ln      net.Listener
+	handler Handler
+}
+
+// Serve starts accepting and serving connections from ln in a background
+// goroutine, and returns immediately. Call Close to stop.
+func Serve(ln net.Listener, handler Handler) *Server {
+	s := &Server{ln: ln, handler: handler}
+	go s.acceptLoop()
+	return s
+}
+
+// Close stops accepting new connections and closes the listener.
+func (s *Server) Close() error {
+	return s.ln.Close()
+}
+
+func (s *Server) acceptLoop() {
+	for {
+		nc, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.serveConn(nc)
+	}
+}
+
+func (s *Server) serveConn(nc net.Conn) {
+	defer nc.Close()
+
+	if _, err := ServerHandshake(nc); err != nil {
+		return
+	}
+
+	var cursor Cursor
+	defer func() {
+		if cursor != nil {
+			cursor.Close()
+		}
+	}()
+
+	for {
+		msg, err := DecodeMessage(nc)
+		if err != nil {
+			return
+		}
+		switch msg.Kind {
+		case KindHello:
+			_ = EncodeMessage(nc, Success(nil))
+		case KindRun:
+			if cursor != nil {
+				cursor.Close()
+				cursor = nil
+			}
+			query, _ := msg.Fields["query"].(string)
+			params, _ := msg.Fields["params"].(map[string]any)
+			c, err := s.handler.Execute(context.Background(), query, params)
+			if err != nil {
+				_ = EncodeMessage(nc, Failure(err.Error()))
+				continue
+			}
+			cursor = c
+			cols := make([]any, len(c.Columns()))
+			for i, name := range c.Columns() {
+				cols[i] = name
+			}
+			_ = EncodeMessage(nc, Success(map[string]any{"fields": cols}))
+		case KindPull:
+			if cursor == nil {
+				_ = EncodeMessage(nc, Failure("PULL with no RUN in progress"))
+				continue
+			}
+			n, _ := msg.Fields["n"].(int64)
+			if err := s.pull(nc, cursor, n); err != nil {
+				return
+			}
+		case KindDiscard, KindReset:
+			if cursor != nil {
+				cursor.Close()
+				cursor = nil
+			}
+			_ = EncodeMessage(nc, Success(nil))
+		case KindBegin, KindCommit, KindRollback:
+			// This reference server has no multi-statement transaction
+			// state to manage; it simply acknowledges these so clients
+			// written against a real transactional server still work.
+			_ = EncodeMessage(nc, Success(nil))
+		case KindGoodbye:
+			return
+		default:
+			_ = EncodeMessage(nc, Failure(fmt.Sprintf("unexpected message kind %d", msg.Kind)))
+		}
+	}
+}
+
+func (s *Server) pull(nc net.Conn, cursor Cursor, n int64) error {
+	count := int64(0)
+	for n < 0 || count < n {
+		row, ok, err := cursor.Next()
+		if err != nil {
+			return EncodeMessage(nc, Failure(err.Error()))
+		}
+		if !ok {
+			return EncodeMessage(nc, Success(map[string]any{"has_more": false}))
+		}
+		if err := EncodeMessage(nc, Record(row)); err != nil {
+			return err
+		}
+		count++
+	}
+	return EncodeMessage(nc, Success(map[string]any{"has_more": true}))
+}
@@ -0,0 +1,67 @@
+// Package wire implements Grafeo's framed binary protocol: a PackStream-like
+// typed value encoding, a version-negotiating handshake, and the
+// HELLO/RUN/PULL/DISCARD/BEGIN/COMMIT/ROLLBACK/RESET/GOODBYE message set
+// Bolt-style drivers use to stream query results without buffering the
+// whole result set.
+package wire
+
+// Node is the wire representation of a graph node: an identity, its
+// labels, and its properties.
+type Node struct {
+	ID         int64
+	Labels     []string
+	Properties map[string]any
+}
+
+// Relationship is the wire representation of a directed, typed edge.
+type Relationship struct {
+	ID          int64
+	Type        string
+	StartNodeID int64
+	EndNodeID   int64
+	Properties  map[string]any
+}
+
+// DOTID, DOTLabels and DOTProperties let Node be rendered directly by
+// grafeo.WriteDOT / grafeo.WriteDOTGraph without that package needing to
+// import wire's value types explicitly for each one.
+func (n Node) DOTID() int64                  { return n.ID }
+func (n Node) DOTLabels() []string           { return n.Labels }
+func (n Node) DOTProperties() map[string]any { return n.Properties }
+
+// DOTID, DOTType, DOTFrom, DOTTo and DOTProperties let Relationship be
+// rendered directly by grafeo.WriteDOT / grafeo.WriteDOTGraph.
+func (r Relationship) DOTID() int64                  { return r.ID }
+func (r Relationship) DOTType() string               { return r.Type }
+func (r Relationship) DOTFrom() int64                { return r.StartNodeID }
+func (r Relationship) DOTTo() int64                  { return r.EndNodeID }
+func (r Relationship) DOTProperties() map[string]any { return r.Properties }
+
+// Path is an alternating sequence of nodes and relationships, as returned
+// by `RETURN path` queries over variable-length patterns.
+type Path struct {
+	Nodes         []Node
+	Relationships []Relationship
+	// Sequence alternates relationship index (1-based, negative if
+	// traversed backwards) and node index into Nodes/Relationships,
+	// mirroring Bolt's compact path encoding.
+	Sequence []int64
+}
+
+// Supported value kinds. null, bool, int64, float64, string and []byte map
+// directly onto their closest Go type; list and map onto []any and
+// map[string]any.
+const (
+	tagNull byte = iota
+	tagTrue
+	tagFalse
+	tagInt
+	tagFloat
+	tagString
+	tagBytes
+	tagList
+	tagMap
+	tagNode
+	tagRelationship
+	tagPath
+)
@@ -0,0 +1,94 @@
+package grafeo
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"grafeo/wire"
+)
+
+// fakeCursor yields a single fixed row, just enough for wireRows tests
+// that only care about connection lifetime, not query results.
+type fakeCursor struct{ done bool }
+
+func (c *fakeCursor) Columns() []string { return []string{"n"} }
+
+func (c *fakeCursor) Next() ([]any, bool, error) {
+	if c.done {
+		return nil, false, nil
+	}
+	c.done = true
+	return []any{int64(1)}, true, nil
+}
+
+func (c *fakeCursor) Close() error { return nil }
+
+type fakeHandler struct{}
+
+func (fakeHandler) Execute(ctx context.Context, query string, params map[string]any) (wire.Cursor, error) {
+	return &fakeCursor{}, nil
+}
+
+func dialTestServer(t *testing.T) *wire.Conn {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := wire.Serve(ln, fakeHandler{})
+	t.Cleanup(func() { srv.Close() })
+
+	conn, err := wire.Dial(ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := conn.Send(wire.Hello("test")); err != nil {
+		t.Fatalf("HELLO: %v", err)
+	}
+	if _, err := conn.Receive(); err != nil {
+		t.Fatalf("HELLO response: %v", err)
+	}
+	return conn
+}
+
+// TestWireRowsClosePreservesPooledConn is a regression test for chunk0-5's
+// pooling bug: a *wireRows that doesn't own its conn (boltConn's case, a
+// persistent connection reused across many queries) must survive Close so
+// the connection the DB pool hands back out still works.
+func TestWireRowsClosePreservesPooledConn(t *testing.T) {
+	conn := dialTestServer(t)
+
+	if _, err := conn.Run("RETURN 1", nil); err != nil {
+		t.Fatalf("RUN: %v", err)
+	}
+	rows := &wireRows{ctx: context.Background(), conn: conn, columns: []string{"n"}, pos: -1}
+	if err := rows.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// The connection must still be usable for another query after Close.
+	if _, err := conn.Run("RETURN 1", nil); err != nil {
+		t.Fatalf("RUN after Close of a non-owning wireRows: %v", err)
+	}
+}
+
+// TestWireRowsCloseOwnedConnGoodbyes covers the opposite case: a *wireRows
+// that does own its conn (Client's dial-per-query transport) must still
+// tear the connection down on Close.
+func TestWireRowsCloseOwnedConnGoodbyes(t *testing.T) {
+	conn := dialTestServer(t)
+
+	if _, err := conn.Run("RETURN 1", nil); err != nil {
+		t.Fatalf("RUN: %v", err)
+	}
+	rows := &wireRows{ctx: context.Background(), conn: conn, columns: []string{"n"}, pos: -1, ownsConn: true}
+	if err := rows.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if _, err := conn.Run("RETURN 1", nil); err == nil {
+		t.Fatal("RUN after Close of an owning wireRows: got nil error, want the closed connection to fail")
+	}
+}
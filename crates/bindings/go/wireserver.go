@@ -0,0 +1,61 @@
+package grafeo
+
+import (
+	"context"
+	"net"
+
+	"grafeo/cypher"
+	"grafeo/wire"
+)
+
+// ServeWire starts a grafeo/wire server on ln backed by exec, the same
+// Executor a *Client or httpsrv.Server would run queries against. This is
+// what lets a *grafeo/memgraph.Graph be driven over the wire protocol -
+// directly, or via a "bolt://" Client/DB for pooling - rather than only
+// in-process. Call Close on the returned Server to stop accepting.
+func ServeWire(ln net.Listener, exec Executor) *wire.Server {
+	return wire.Serve(ln, executorHandler{exec: exec})
+}
+
+// executorHandler adapts an Executor to wire.Handler, bridging Rows'
+// Scan-based iteration to Cursor's Next() ([]any, bool, error).
+type executorHandler struct {
+	exec Executor
+}
+
+func (h executorHandler) Execute(ctx context.Context, query string, params map[string]any) (wire.Cursor, error) {
+	q, err := cypher.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := h.exec.Execute(ctx, q, params)
+	if err != nil {
+		return nil, err
+	}
+	return &rowsCursor{rows: rows}, nil
+}
+
+// rowsCursor adapts a Rows to wire.Cursor for executorHandler.
+type rowsCursor struct {
+	rows Rows
+}
+
+func (c *rowsCursor) Columns() []string { return c.rows.Columns() }
+
+func (c *rowsCursor) Next() ([]any, bool, error) {
+	if !c.rows.Next() {
+		return nil, false, c.rows.Err()
+	}
+	cols := c.rows.Columns()
+	dest := make([]any, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range dest {
+		ptrs[i] = &dest[i]
+	}
+	if err := c.rows.Scan(ptrs...); err != nil {
+		return nil, false, err
+	}
+	return dest, true, nil
+}
+
+func (c *rowsCursor) Close() error { return c.rows.Close() }
@@ -0,0 +1,49 @@
+package grafeo_test
+
+import (
+	"net"
+	"testing"
+
+	"grafeo"
+	"grafeo/memgraph"
+	"grafeo/wire"
+)
+
+// TestServeWireAgainstMemgraph is a regression test for chunk0-3's server
+// shim: it stands up a real wire.Server backed by a *memgraph.Graph via
+// ServeWire (rather than the hand-rolled fakeHandler in wirerows_test.go)
+// and runs a query over the wire against real graph data.
+func TestServeWireAgainstMemgraph(t *testing.T) {
+	g := memgraph.New()
+	g.AddNode([]string{"Person"}, map[string]any{"name": "Ann"})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := grafeo.ServeWire(ln, g)
+	t.Cleanup(func() { srv.Close() })
+
+	conn, err := wire.Dial(ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := conn.Send(wire.Hello("test")); err != nil {
+		t.Fatalf("HELLO: %v", err)
+	}
+	if _, err := conn.Receive(); err != nil {
+		t.Fatalf("HELLO response: %v", err)
+	}
+
+	if _, err := conn.Run("MATCH (p:Person) RETURN p.name", nil); err != nil {
+		t.Fatalf("RUN: %v", err)
+	}
+	records, _, err := conn.Pull(-1)
+	if err != nil {
+		t.Fatalf("Pull: %v", err)
+	}
+	if len(records) != 1 || records[0][0] != "Ann" {
+		t.Fatalf("got records %v, want [[Ann]]", records)
+	}
+}